@@ -2,19 +2,25 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"os/signal"
+	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/Fastdev75/xsearch/internal/httpclient"
 	"github.com/Fastdev75/xsearch/internal/output"
 	"github.com/Fastdev75/xsearch/internal/scanner"
 	"github.com/Fastdev75/xsearch/internal/utils"
@@ -26,46 +32,198 @@ const repoOwner = "Fastdev75"
 const repoName = "xsearch"
 
 func main() {
+	os.Exit(run())
+}
+
+// run parses the flags and scans the targets, returning the exit code. Errors
+// of the scan itself are returned instead of exiting, so the deferred closing
+// of the output files and sinks still runs.
+func run() int {
 	// Essential flags only
 	targetURL := flag.String("u", "", "Target URL (required)")
-	wordlistPath := flag.String("w", "", "Custom wordlist path")
+	targetList := flag.String("l", "", "File of target URLs, one per line, scanned in turn")
+	hostConcurrency := flag.Int("hc", 1, "Targets of -l scanned in parallel, sharing the -t connection budget")
+	wordlistPath := flag.String("w", "", "Custom wordlist path (comma-separated for several)")
+	streamWords := flag.Bool("stream", false, "Stream the wordlist from disk instead of loading it (huge lists)")
+	wordlistStats := flag.Bool("wl-stats", false, "Print wordlist statistics before scanning")
 	outputFile := flag.String("o", "", "Output file")
+	writeCodes := flag.String("write-codes", "", "Status codes written to the output file (default: 200,301,302,307,308,401,403)")
+	saveResponses := flag.String("save-responses", "", "Save the body of each finding into this directory (with manifest.json)")
+	checkpoint := flag.Duration("checkpoint", 0, "Save the output files every interval during the scan (e.g. 60s)")
+	jsonFile := flag.String("oJ", "", "Also write findings as NDJSON to this file (independent of -o/-of)")
+	allFile := flag.String("oA", "", "Write findings to <base>.txt, <base>.json and <base>.tree at once")
+	graphFile := flag.String("o-graph", "", "Write discovered paths as a JSON node/edge graph")
+	statusDir := flag.String("o-by-status", "", "Write findings to one file per status code in this directory")
+	dbPath := flag.String("db", "", "Append findings to a SQLite database (needs a -tags sqlite build)")
+	syslogOn := flag.Bool("syslog", false, "Send findings to syslog (RFC 5424)")
+	syslogAddr := flag.String("syslog-addr", "udp://127.0.0.1:514", "Syslog server (udp://host:port or tcp://host:port)")
+	outputFormat := flag.String("of", output.FormatTree, "Output file format: tree, dirsearch, gobuster, json, json-compact")
 	threads := flag.Int("t", 50, "Threads (default: 50)")
+	retries := flag.Int("retries", 0, "Retry timeouts, connection resets and 502/503/504 this many times with backoff")
+	rate := flag.Int("rate", 0, "Max requests per second across all threads (0 = unlimited)")
+	threadsAuto := flag.Bool("threads-auto", false, "Tune threads to response latency between -threads-min and -threads-max")
+	threadsMin := flag.Int("threads-min", 10, "Starting and lowest thread count with -threads-auto")
+	threadsMax := flag.Int("threads-max", 300, "Highest thread count with -threads-auto")
 	extensions := flag.String("x", "", "Extensions (e.g., php,html,js)")
+	extProfiles := flag.String("x-profile", "", "Extension profiles (e.g., web,backup,java)")
+	prefixes := flag.String("prefix", "", "Also try each word with these prefixes, comma-separated (e.g., dev-,old_)")
+	suffixes := flag.String("suffix", "", "Also try each word with these suffixes, comma-separated (e.g., -old,_bak)")
+	mutateCase := flag.Bool("mutate-case", false, "Also try the lowercase, uppercase and Title-case forms of each word")
+	dotFiles := flag.Bool("dotfiles", false, "Also probe hidden .word and .word.ext variants")
+	reportEmpty := flag.Bool("report-empty", false, "Report 0-byte responses, bypassing soft-404 and confidence checks")
+	stemExpand := flag.Bool("stem-expand", false, "Probe found files with related extensions (backup.zip -> backup.rar, ...)")
+	backups := flag.Bool("backup", false, "Probe backup copies of found files (config.php.bak, config.php~, ...)")
+	follow := flag.Bool("follow", false, "Follow redirects and report the chain and final URL")
+	maxRedirects := flag.Int("max-redirects", 10, "Redirect hops followed with -follow")
+	encodeVariants := flag.Bool("encode-variants", false, "Also probe percent-encoded/decoded forms of words with special characters")
+	caseEvade := flag.Bool("case-evade", false, "Randomize the case of each probed word (case-insensitive servers only)")
+	apiExpand := flag.Bool("api-expand", false, "Probe API versions (v1, v2, v3, latest) under api/rest/graphql directories")
+	noDotSkip := flag.Bool("no-dot-skip", false, "Also probe dotted words (v1.2, api.v2) as directories")
+	headOnly := flag.Bool("head-only", false, "HEAD requests only, no verification GET (faster, less accurate)")
+	maxDownload := flag.String("max-download", "", "Stop reading bodies after this much data, e.g. 500MB (then HEAD-only)")
+	sizeMismatch := flag.Bool("cl-mismatch", false, "Annotate findings whose HEAD Content-Length differs from the GET body")
 	timeout := flag.Int("timeout", 10, "Timeout in seconds (default: 10)")
+	maxErrors := flag.Int("max-errors", 0, "Consecutive errors before the circuit breaker trips (0 = off)")
+	errorCooldown := flag.Duration("cooldown", 0, "Pause after the circuit breaker trips (0 = abort)")
+	replayDelay := flag.Duration("replay-delay", 0, "On 429/503, slow to one request per delay and recover gradually (0 = off)")
+	delay := flag.String("delay", "", "Delay after each request per worker, or a random range (e.g., 100ms, 50-200ms)")
+	noKeepAlive := flag.Bool("no-keepalive", false, "Open a new connection for every request")
+	idleTimeout := flag.Duration("idle-timeout", 0, "Close pooled connections idle this long (default: 120s)")
+
+	// Authentication, proxy and headers
+	ntlmCreds := flag.String("ntlm", "", "NTLM credentials ([DOMAIN\\]user:pass)")
+	proxyURL := flag.String("proxy", "", "Proxy URL: http://, https:// or socks5:// (e.g. http://127.0.0.1:8080)")
+	basicAuth := flag.String("auth", "", "HTTP basic auth credentials (user:pass)")
+	bearer := flag.String("bearer", "", "Bearer token sent as Authorization: Bearer <token>")
+	var headerLines headerFlags
+	flag.Var(&headerLines, "H", "Custom header \"Name: Value\" (repeatable)")
+	cookieJarFile := flag.String("cookie-jar", "", "Netscape cookies.txt file (browser/curl export)")
+	query := flag.String("query", "", "Query string appended to every request (e.g., apikey=X&v=2)")
+	randomAgent := flag.Bool("random-agent", false, "Send a random browser User-Agent with every request")
+	cacheBust := flag.Bool("cache-bust", false, "Append a random query parameter to every request (bypass caches)")
+
+	// Raw request templating
+	requestFile := flag.String("request", "", "Raw HTTP request file with FUZZ marker")
+	requestProto := flag.String("request-proto", "https", "Scheme for -request (http or https)")
+
+	// Custom method and body
+	method := flag.String("X", "", "HTTP method for every probe, replacing HEAD then GET (e.g., POST, PROPFIND)")
+	data := flag.String("data", "", "Request body sent with -X (implies -X POST)")
+
+	// Post-processing
+	enumMethods := flag.Bool("enum-methods", false, "Enumerate allowed HTTP methods per finding (OPTIONS)")
+	fingerprint := flag.Bool("fingerprint", false, "Fingerprint the web server and framework before scanning")
+	faviconHash := flag.Bool("favicon-hash", false, "Print the Shodan favicon hash (mmh3) of /favicon.ico and the product it matches")
+	fingerprintExt := flag.Bool("fingerprint-ext", false, "Fingerprint and add extensions for the detected stack")
 
 	// Simple toggles
+	rootFirst := flag.Bool("scan-root-first", true, "Probe and report the base URL before calibration")
 	noRecursive := flag.Bool("nr", false, "Disable recursive mode")
 	depth := flag.Int("d", 10, "Max recursion depth (default: 10)")
+	minFindings := flag.Int("recurse-min-findings", 0, "Recurse only into directories whose parent yielded N findings")
+	unified := flag.Bool("unified", false, "Scan directories and files in one pass instead of three phases")
+	confirm := flag.Bool("confirm", false, "Re-request findings after the scan and drop those no longer reliable")
+	maxProbes := flag.Int("maxreq", 0, "Stop the scan after N probed URLs, as counted by the Requests stat (0 = no limit)")
+	maxDirs := flag.Int("max-dirs", 0, "Recurse into and scan files in at most N directories, shallow first (0 = no limit)")
+	skipExts := flag.String("recurse-skip-ext", "", "Skip directories dominated by these extensions (e.g., png,jpg,css,woff)")
+	pathDepths := flag.String("d-path", "", "Per-path max depth (e.g., /api:20,/static:0)")
 
 	// Filtering (advanced)
 	filterCodes := flag.String("fc", "", "Filter status codes (e.g., 403,500)")
+	dirCodes := flag.String("dir-codes", "", "Status codes that mean a directory and are recursed into (default: 200,301,302,307,308)")
+	recurseCodes := flag.String("rc", "", "Status codes of the directories recursed into (default: -dir-codes, or 200,301,302,307,308)")
+	matchCodes := flag.String("mc", "", "Show only these status codes (e.g., 200,301)")
 	filterSize := flag.String("fs", "", "Filter by size")
+	filterURL := flag.String("filter-regex-url", "", "Skip candidate URLs matching this regex")
+	recurseOnly := flag.String("recurse-only", "", "Recurse only into directories whose name matches this regex")
+	robots := flag.Bool("robots", false, "Probe the Allow/Disallow paths of robots.txt before Phase 1")
+	wellKnown := flag.Bool("well-known", false, "Probe standard /.well-known/ resources (security.txt, openid-configuration, ...)")
+	passive := flag.Bool("passive", false, "No brute force: only check paths from robots.txt, sitemaps, security.txt and homepage links")
+	calibrateOnly := flag.Bool("calibrate-only", false, "Report how the target answers missing resources, then exit")
+	calSamples := flag.Int("cal-samples", 10, "Missing resources requested by -calibrate-only")
+	calMethod := flag.String("cal-method", "GET", "HTTP method for soft-404 calibration (match the scan)")
+	soft404Hash := flag.Bool("soft404-require-hash", false, "Soft-404 needs a body hash match, not just equal size")
+	excludeHome := flag.Bool("exclude-homepage", false, "Drop findings identical to the homepage")
+	sizeDev := flag.Float64("size-deviation", 0, "Only report sizes deviating more than this % from the baseline")
+	minConfidence := flag.Float64("min-confidence", 0, "Hide findings scoring below this confidence (0-1)")
+	matchWords := flag.String("mw", "", "Match body word count (e.g., 42,57)")
+	filterWords := flag.String("fw", "", "Filter body word count (e.g., 42,57)")
+	filterLines := flag.String("fl", "", "Filter body line count (e.g., 12,30)")
+	matchTypes := flag.String("mct", "", "Match Content-Type substrings (e.g., json,xml)")
+	filterTypes := flag.String("fct", "", "Filter Content-Type substrings (e.g., image/,font/)")
+	contains := flag.String("contains", "", "Only report responses whose body contains this text")
+	containsNoCase := flag.Bool("contains-i", false, "Match -contains case-insensitively")
 
 	// Display options
 	silent := flag.Bool("q", false, "Quiet mode (no banner)")
+	timingStats := flag.Bool("timing-stats", false, "Report response time percentiles (p50/p90/p99) at the end")
+	progressEvery := flag.Duration("progress-interval", 500*time.Millisecond, "Progress line refresh interval (0 = no progress)")
+	quietErrors := flag.Bool("quiet-errors", false, "Never print request errors, even with -verbose")
+	verboseErrors := flag.Bool("verbose", false, "Print the first request errors as they happen")
+	rulesFile := flag.String("rules", "", "Tagging rules file (pattern [codes] => tag)")
+	reportHashesFile := flag.String("report-hashes", "", "File of body MD5s always reported, even if filtered or soft-404")
+	stdoutFormat := flag.String("stdout-format", "", "Terminal line template (e.g., \"{status} {size} {url}\")")
+	showSource := flag.Bool("source", false, "Show discovery source per finding")
+	colorMode := flag.String("color", "auto", "Color output: auto, always, never")
 	showVersion := flag.Bool("v", false, "Version")
 	showHelp := flag.Bool("h", false, "Help")
 	doUpgrade := flag.Bool("up", false, "Auto-upgrade to latest version")
+	merge := flag.Bool("merge", false, "Merge JSON findings files (positional) into one report")
+	selfTest := flag.Bool("self-test", false, "Scan a built-in test server and verify the results")
+	flag.Bool("json-errors", false, "Report fatal errors as JSON on stderr with category exit codes")
+
+	// -json-errors must also cover flag parsing errors, so look for it first
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	if hasBoolFlag(os.Args[1:], "json-errors") {
+		utils.SetJSONErrors(true)
+		flag.CommandLine.SetOutput(io.Discard)
+	}
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		if !hasBoolFlag(os.Args[1:], "json-errors") {
+			return 2 // The flag package already printed the error and usage
+		}
+		utils.Fatal(utils.ErrConfig, "%s", err)
+	}
 
-	flag.Parse()
+	if err := utils.ApplyColorMode(*colorMode); err != nil {
+		utils.Fatal(utils.ErrConfig, "%s", err)
+	}
 
 	if *showVersion {
 		fmt.Printf("xsearch v%s - Fast Content Discovery\n", version)
-		os.Exit(0)
+		return 0
 	}
 
 	if *doUpgrade {
 		if err := selfUpgrade(); err != nil {
-			utils.PrintError("Upgrade failed: %v", err)
-			os.Exit(1)
+			utils.Fatal(utils.ErrInternal, "Upgrade failed: %v", err)
+		}
+		return 0
+	}
+
+	// Hidden: smoke test and benchmark against an in-process server
+	if *selfTest {
+		if !runSelfTest(*threads) {
+			return 1
+		}
+		return 0
+	}
+
+	// Merge mode: combine earlier scans, no scanning
+	if *merge {
+		files, err := mergeFiles(flag.Args())
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
 		}
-		os.Exit(0)
+		if err := runMerge(files, *outputFile, *outputFormat); err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+		return 0
 	}
 
-	if *showHelp || *targetURL == "" {
+	if *showHelp || (*targetURL == "" && *requestFile == "" && *targetList == "") {
 		printHelp()
-		os.Exit(0)
+		return 0
 	}
 
 	if !*silent {
@@ -74,14 +232,21 @@ func main() {
 
 	// Parse extensions - use defaults if not specified for complete discovery
 	var exts []string
+	if *extProfiles != "" {
+		profileExts, err := scanner.ExpandProfiles(*extProfiles)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+		exts = append(exts, profileExts...)
+	}
 	if *extensions != "" {
 		for _, ext := range strings.Split(*extensions, ",") {
 			ext = strings.TrimSpace(strings.TrimPrefix(ext, "."))
-			if ext != "" {
+			if ext != "" && !containsString(exts, ext) {
 				exts = append(exts, ext)
 			}
 		}
-	} else {
+	} else if len(exts) == 0 {
 		// Default extensions - comprehensive web content discovery
 		exts = []string{
 			// Web scripts
@@ -103,15 +268,11 @@ func main() {
 		}
 	}
 
+	var err error
+
 	// Parse filter codes
-	var filtCodes []int
-	if *filterCodes != "" {
-		for _, c := range strings.Split(*filterCodes, ",") {
-			if code, err := strconv.Atoi(strings.TrimSpace(c)); err == nil {
-				filtCodes = append(filtCodes, code)
-			}
-		}
-	}
+	filtCodes := parseIntList(*filterCodes)
+	writeCodeList := parseIntList(*writeCodes)
 
 	// Parse filter sizes
 	var filtSizes []int64
@@ -123,66 +284,856 @@ func main() {
 		}
 	}
 
-	// Load wordlist
-	wlManager, err := wordlist.NewManager(*wordlistPath)
+	// Parse raw request template
+	var rawRequest *httpclient.RawRequest
+	if *requestFile != "" {
+		rawRequest, err = httpclient.LoadRawRequest(*requestFile, *requestProto)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+		if !rawRequest.HasMarker() {
+			utils.Fatal(utils.ErrConfig, "request file has no %s marker", httpclient.FuzzMarker)
+		}
+		if *targetURL != "" {
+			utils.Fatal(utils.ErrConfig, "-u cannot be used with -request, whose target is its Host")
+		}
+		*targetURL = rawRequest.BaseURL()
+	}
+
+	// Probe method: -data alone means POST, like curl
+	*method = strings.ToUpper(strings.TrimSpace(*method))
+	if *data != "" && *method == "" {
+		*method = "POST"
+	}
+	if *method != "" && *requestFile != "" {
+		utils.Fatal(utils.ErrConfig, "-X/-data cannot be used with -request, which sets its own method and body")
+	}
+	if *method != "" {
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "cal-method" {
+				utils.Fatal(utils.ErrConfig, "-cal-method cannot be used with -X/-data, which calibration already uses")
+			}
+		})
+	}
+
+	// Targets: -u, or every line of -l
+	targets := []string{*targetURL}
+	if *targetList != "" {
+		if *requestFile != "" {
+			utils.Fatal(utils.ErrConfig, "-l cannot be used with -request, whose target is its Host")
+		}
+		if *targetURL != "" {
+			utils.Fatal(utils.ErrConfig, "-u and -l conflict, use one")
+		}
+		targets, err = readTargets(*targetList)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+		utils.PrintInfo("Targets: %d from %s", len(targets), *targetList)
+	}
+	if *hostConcurrency < 1 {
+		utils.Fatal(utils.ErrConfig, "-hc must be at least 1")
+	}
+
+	// Parse per-path depth overrides
+	pathDepthMap, err := parsePathDepths(*pathDepths)
 	if err != nil {
-		utils.PrintError("%s", err)
-		os.Exit(1)
+		utils.Fatal(utils.ErrConfig, "%s", err)
+	}
+
+	// Compile candidate URL filter
+	var filterURLRegex *regexp.Regexp
+	if *filterURL != "" {
+		filterURLRegex, err = regexp.Compile(*filterURL)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "invalid -filter-regex-url: %s", err)
+		}
+	}
+
+	// Compile recursion directory filter
+	var recurseOnlyRegex *regexp.Regexp
+	if *recurseOnly != "" {
+		recurseOnlyRegex, err = regexp.Compile(*recurseOnly)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "invalid -recurse-only: %s", err)
+		}
+	}
+
+	// Parse static asset extensions
+	var skipExtList []string
+	for _, ext := range strings.Split(*skipExts, ",") {
+		if ext = strings.TrimSpace(strings.TrimPrefix(ext, ".")); ext != "" {
+			skipExtList = append(skipExtList, ext)
+		}
+	}
+
+	// -unified schedules a directory as soon as it is found, before its
+	// siblings are known
+	if *unified && *minFindings > 0 {
+		utils.Fatal(utils.ErrConfig, "-recurse-min-findings needs complete directory phases and cannot be used with -unified")
+	}
+
+	// Parse word and line count matchers
+	matchWordCounts := parseIntList(*matchWords)
+	filterWordCounts := parseIntList(*filterWords)
+	filterLineCounts := parseIntList(*filterLines)
+	if *headOnly && (len(matchWordCounts) > 0 || len(filterWordCounts) > 0 || len(filterLineCounts) > 0 ||
+		*soft404Hash || *contains != "") {
+		utils.Fatal(utils.ErrConfig, "-mw, -fw, -fl, -contains and -soft404-require-hash need response bodies and cannot be used with -head-only")
+	}
+
+	// Load tagging rules
+	var rules []scanner.Rule
+	if *rulesFile != "" {
+		rules, err = scanner.LoadRules(*rulesFile)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+	}
+
+	// Per-worker delay between requests
+	delayMin, delayMax, err := parseDelay(*delay)
+	if err != nil {
+		utils.Fatal(utils.ErrConfig, "Invalid -delay: %v", err)
+	}
+
+	// Body download budget
+	maxDownloadBytes, err := parseByteSize(*maxDownload)
+	if err != nil {
+		utils.Fatal(utils.ErrConfig, "Invalid -max-download: %s", err)
+	}
+
+	// Body hashes to always report
+	var reportHashes []string
+	if *reportHashesFile != "" {
+		reportHashes, err = scanner.LoadHashes(*reportHashesFile)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+		if *headOnly {
+			utils.Fatal(utils.ErrConfig, "-report-hashes needs response bodies and cannot be used with -head-only")
+		}
+	}
+	if *saveResponses != "" && *headOnly {
+		utils.Fatal(utils.ErrConfig, "-save-responses needs response bodies and cannot be used with -head-only")
+	}
+
+	// Load wordlist (a calibration report or passive scan needs none)
+	var wlManager *wordlist.Manager
+	var words []string
+	var wordCount int
+	if !*calibrateOnly && !*passive {
+		wlManager, err = wordlist.NewManager(*wordlistPath)
+		if err != nil {
+			utils.Fatal(utils.ErrWordlist, "%s", err)
+		}
+		if *streamWords {
+			wordCount, err = wlManager.Open()
+		} else {
+			words, err = wlManager.Load()
+		}
+		if err != nil {
+			utils.Fatal(utils.ErrWordlist, "%s", err)
+		}
+		if *wordlistStats {
+			if *streamWords {
+				utils.PrintWarning("-wl-stats needs the loaded wordlist, ignored with -stream")
+			} else {
+				wlManager.PrintStats()
+			}
+		}
+	}
+
+	if *threadsAuto {
+		if *threadsMin < 1 || *threadsMax < *threadsMin {
+			utils.Fatal(utils.ErrConfig, "-threads-auto needs 1 <= -threads-min <= -threads-max")
+		}
+		*threads = *threadsMax
+	}
+
+	if *minConfidence < 0 || *minConfidence > 1 {
+		utils.Fatal(utils.ErrConfig, "-min-confidence must be between 0 and 1")
 	}
 
-	words, err := wlManager.Load()
+	// Validate the terminal line template up front
+	if *stdoutFormat != "" {
+		if _, err := output.ParseTemplate(*stdoutFormat); err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+	}
+
+	// Fixed query parameters
+	queryValues, err := url.ParseQuery(strings.TrimPrefix(*query, "?"))
 	if err != nil {
-		utils.PrintError("%s", err)
-		os.Exit(1)
+		utils.Fatal(utils.ErrConfig, "Invalid -query: %s", err)
+	}
+
+	// Custom headers
+	headers := make(http.Header)
+	for _, line := range headerLines {
+		name, value, err := httpclient.ParseHeader(line)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "Invalid -H: %s", err)
+		}
+		headers.Add(name, value)
+	}
+
+	// Basic or bearer authentication, sent with calibration requests too
+	if *basicAuth != "" || *bearer != "" {
+		if *basicAuth != "" && *bearer != "" {
+			utils.Fatal(utils.ErrConfig, "-auth and -bearer conflict, use one")
+		}
+		if headers.Get("Authorization") != "" {
+			utils.Fatal(utils.ErrConfig, "-auth/-bearer conflict with -H Authorization")
+		}
+		authorization := "Bearer " + *bearer
+		if *basicAuth != "" {
+			authorization, err = httpclient.BasicAuth(*basicAuth)
+			if err != nil {
+				utils.Fatal(utils.ErrConfig, "Invalid -auth: %s", err)
+			}
+		}
+		headers.Set("Authorization", authorization)
+	}
+
+	// Upstream proxy
+	var proxy *url.URL
+	if *proxyURL != "" {
+		proxy, err = httpclient.ParseProxy(*proxyURL)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+		utils.PrintInfo("Proxy: %s", proxy.Redacted())
+	}
+
+	// NTLM authentication
+	var ntlm *httpclient.NTLMCredentials
+	if *ntlmCreds != "" {
+		ntlm, err = httpclient.ParseNTLMCredentials(*ntlmCreds)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+		// A fixed Authorization header would replace the handshake messages
+		if headers.Get("Authorization") != "" {
+			utils.Fatal(utils.ErrConfig, "-ntlm conflicts with -auth, -bearer and -H Authorization")
+		}
+		if *noKeepAlive {
+			utils.PrintWarning("-no-keepalive breaks NTLM, which authenticates the connection")
+		}
+	}
+
+	// Session cookies from a browser/curl export
+	var jar http.CookieJar
+	if *cookieJarFile != "" {
+		var loaded int
+		jar, loaded, err = httpclient.LoadCookieJar(*cookieJarFile)
+		if err != nil {
+			utils.Fatal(utils.ErrConfig, "%s", err)
+		}
+		utils.PrintInfo("Cookie jar: %d cookies loaded", loaded)
 	}
 
 	// Output writer
-	writer, err := output.NewWriter(*outputFile)
+	writer, err := output.NewWriter(*outputFile, *outputFormat)
 	if err != nil {
-		utils.PrintError("%s", err)
-		os.Exit(1)
+		utils.Fatal(utils.ErrOutput, "%s", err)
 	}
 	defer writer.Close()
+	// Paths alone would not tell the hosts of -l apart
+	multiTarget := len(targets) > 1
+	if multiTarget {
+		writer.SetFullURLs()
+	}
+	checkpointWriters := []*output.Writer{writer}
 
 	// Config with optimized defaults for speed
 	config := &scanner.Config{
-		TargetURL:    *targetURL,
-		Words:        words,
-		Threads:      *threads,
-		Timeout:      time.Duration(*timeout) * time.Second,
-		UserAgent:    "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
-		Extensions:   exts,
-		Recursive:    !*noRecursive, // Recursive ON by default
-		MaxDepth:     *depth,
-		AddSlash:     true, // Add slash ON by default
-		FilterCodes:  filtCodes,
-		ExcludeSizes: filtSizes,
-	}
-
-	engine := scanner.NewEngine(config, writer)
-
-	// Signal handling
+		TargetURL:      *targetURL,
+		Words:          words,
+		WordCount:      wordCount,
+		Threads:        *threads,
+		Timeout:        time.Duration(*timeout) * time.Second,
+		UserAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		Extensions:     exts,
+		Recursive:      !*noRecursive, // Recursive ON by default
+		MaxDepth:       *depth,
+		PathDepths:     pathDepthMap,
+		SkipExts:       skipExtList,
+		MinFindings:    *minFindings,
+		MaxDirs:        *maxDirs,
+		MaxProbes:      *maxProbes,
+		Confirm:        *confirm,
+		AddSlash:       true, // Add slash ON by default
+		RootFirst:      *rootFirst,
+		DotFiles:       *dotFiles,
+		MutateCase:     *mutateCase,
+		Prefixes:       parseList(*prefixes),
+		Suffixes:       parseList(*suffixes),
+		CaseEvade:      *caseEvade,
+		EncodeVariants: *encodeVariants,
+		StemExpand:     *stemExpand,
+		Backups:        *backups,
+		Follow:         *follow,
+		MaxRedirects:   *maxRedirects,
+		Unified:        *unified,
+		ReportEmpty:    *reportEmpty,
+		ThreadsAuto:    *threadsAuto,
+		ThreadsMin:     *threadsMin,
+		Rate:           *rate,
+		Retries:        *retries,
+		NoDotSkip:      *noDotSkip,
+		APIExpand:      *apiExpand,
+		QuietErrors:    *quietErrors,
+		VerboseErrors:  *verboseErrors,
+		ProgressEvery:  *progressEvery,
+		TimingStats:    *timingStats,
+		HeadOnly:       *headOnly,
+		MaxDownload:    maxDownloadBytes,
+		SizeMismatch:   *sizeMismatch,
+		Soft404Hash:    *soft404Hash,
+		FilterURL:      filterURLRegex,
+		RecurseOnly:    recurseOnlyRegex,
+		Rules:          rules,
+		ReportHashes:   reportHashes,
+		StatusCodes:    parseIntList(*matchCodes),
+		FilterCodes:    filtCodes,
+		DirCodes:       parseIntList(*dirCodes),
+		RecurseCodes:   parseIntList(*recurseCodes),
+		WriteCodes:     writeCodeList,
+		ExcludeSizes:   filtSizes,
+		MatchWords:     matchWordCounts,
+		FilterWords:    filterWordCounts,
+		FilterLines:    filterLineCounts,
+		MatchTypes:     parseList(*matchTypes),
+		FilterTypes:    parseList(*filterTypes),
+		Contains:       *contains,
+		ContainsNoCase: *containsNoCase,
+		SizeDev:        *sizeDev,
+		MinConfidence:  *minConfidence,
+		ShowSource:     *showSource,
+		LineTemplate:   *stdoutFormat,
+		RawRequest:     rawRequest,
+		EnumMethods:    *enumMethods,
+		Fingerprint:    *fingerprint || *fingerprintExt,
+		AutoExtensions: *fingerprintExt,
+		FaviconHash:    *faviconHash,
+		Passive:        *passive,
+		WellKnown:      *wellKnown,
+		Robots:         *robots,
+		SaveResponses:  *saveResponses != "",
+		ExcludeHome:    *excludeHome,
+		CalMethod:      *calMethod,
+		RandomAgent:    *randomAgent,
+		Method:         *method,
+		Data:           *data,
+
+		MaxErrors:     *maxErrors,
+		ErrorCooldown: *errorCooldown,
+		ReplayDelay:   *replayDelay,
+		DelayMin:      delayMin,
+		DelayMax:      delayMax,
+		Query:         queryValues,
+		CacheBust:     *cacheBust,
+		Jar:           jar,
+		Headers:       headers,
+		Proxy:         proxy,
+		NTLM:          ntlm,
+		NoKeepAlive:   *noKeepAlive,
+		IdleTimeout:   *idleTimeout,
+	}
+
+	if *streamWords {
+		config.WordStream = wlManager.Words
+	}
+
+	// Parallel targets share -t: each host still runs at most -t requests,
+	// and all hosts together never have more than -t requests in flight.
+	// Idle connections are pooled per target until its scan ends.
+	if *hostConcurrency > 1 && len(targets) > 1 {
+		config.ConnLimit = scanner.NewConnLimit(*threads)
+		config.ProgressEvery = 0 // Progress lines of parallel targets would overwrite each other
+		utils.PrintInfo("Scanning %d targets at a time, %d connections shared", min(*hostConcurrency, len(targets)), *threads)
+	}
+
+	// Calibration report only, no scan
+	if *calibrateOnly {
+		for _, target := range targets {
+			cfg := *config
+			cfg.TargetURL = target
+			if err := scanner.NewEngine(&cfg, writer).CalibrationReport(*calSamples); err != nil {
+				if len(targets) == 1 {
+					utils.Fatal(utils.ErrTarget, "%s", err)
+				}
+				utils.PrintError("%s: %s", target, err)
+			}
+		}
+		return 0
+	}
+
+	// Extra outputs, shared by the engines of every target: sinks get every
+	// reported finding, outputs only what -o gets
+	var sinks, outputs []output.Sink
+
+	// Path graph output
+	if *graphFile != "" {
+		graphWriter := output.NewGraphWriter(*graphFile)
+		defer func() {
+			if err := graphWriter.Close(); err != nil {
+				utils.PrintError("Failed to write graph: %s", err)
+			}
+		}()
+		sinks = append(sinks, graphWriter)
+	}
+
+	// NDJSON copy of the findings, alongside -o
+	if *jsonFile != "" {
+		jsonWriter, err := output.NewWriter(*jsonFile, output.FormatJSON)
+		if err != nil {
+			utils.Fatal(utils.ErrOutput, "%s", err)
+		}
+		defer func() {
+			if err := jsonWriter.Close(); err != nil {
+				utils.PrintError("Failed to write JSON: %s", err)
+				return
+			}
+			utils.PrintSuccess("Saved JSON to: %s", *jsonFile)
+		}()
+		sinks = append(sinks, jsonWriter)
+		checkpointWriters = append(checkpointWriters, jsonWriter)
+	}
+
+	// Plaintext, JSON and tree copies under one basename
+	if *allFile != "" {
+		multiWriter, err := output.NewMultiWriter(*allFile)
+		if err != nil {
+			utils.Fatal(utils.ErrOutput, "%s", err)
+		}
+		defer func() {
+			if err := multiWriter.Close(); err != nil {
+				utils.PrintError("Failed to write -oA outputs: %s", err)
+				return
+			}
+			utils.PrintSuccess("Saved to: %s", strings.Join(multiWriter.Paths(), ", "))
+		}()
+		if multiTarget {
+			multiWriter.SetFullURLs()
+		}
+		outputs = append(outputs, multiWriter)
+		checkpointWriters = append(checkpointWriters, multiWriter.Writers()...)
+	}
+
+	// Response bodies for offline review
+	if *saveResponses != "" {
+		saver, err := output.NewResponseSaver(*saveResponses)
+		if err != nil {
+			utils.Fatal(utils.ErrOutput, "%s", err)
+		}
+		defer func() {
+			if err := saver.Close(); err != nil {
+				utils.PrintError("Failed to write response manifest: %s", err)
+				return
+			}
+			utils.PrintSuccess("Saved %d responses to: %s", saver.Files(), *saveResponses)
+		}()
+		sinks = append(sinks, saver)
+	}
+
+	// One file per status code
+	if *statusDir != "" {
+		statusWriter, err := output.NewStatusWriter(*statusDir, *outputFormat)
+		if err != nil {
+			utils.Fatal(utils.ErrOutput, "%s", err)
+		}
+		defer func() {
+			if err := statusWriter.Close(); err != nil {
+				utils.PrintError("Failed to write status files: %s", err)
+				return
+			}
+			utils.PrintSuccess("Saved %d status files to: %s", statusWriter.Files(), *statusDir)
+		}()
+		if multiTarget {
+			statusWriter.SetFullURLs()
+		}
+		sinks = append(sinks, statusWriter)
+	}
+
+	// SQLite database
+	var dbWriter *output.DBWriter
+	if *dbPath != "" {
+		dbWriter, err = output.NewDBWriter(*dbPath, targets[0])
+		if err != nil {
+			utils.Fatal(utils.ErrOutput, "%s", err)
+		}
+		// Added per target by ForTarget
+		defer dbWriter.Close()
+	}
+
+	// Syslog forwarding
+	if *syslogOn {
+		syslogWriter, err := output.NewSyslogWriter(*syslogAddr, "xsearch")
+		if err != nil {
+			utils.Fatal(utils.ErrOutput, "%s", err)
+		}
+		defer syslogWriter.Close()
+		sinks = append(sinks, syslogWriter)
+	}
+
+	// The engines of the targets being scanned, for the signal handlers
+	var enginesMux sync.Mutex
+	engines := make(map[*scanner.Engine]bool)
+	stopped := false
+
+	// Signal handling stops the running targets and the rest of the batch
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-sigChan
 		fmt.Println()
 		utils.PrintWarning("Stopping...")
-		engine.Stop()
+		enginesMux.Lock()
+		stopped = true
+		for engine := range engines {
+			engine.Stop()
+		}
+		enginesMux.Unlock()
+	}()
+
+	// SIGHUP reloads the wordlist and queues any new entries.
+	// Streamed wordlists are re-read by every phase instead.
+	hupChan := make(chan os.Signal, 1)
+	if !*streamWords && wlManager != nil {
+		signal.Notify(hupChan, syscall.SIGHUP)
+	}
+	go func() {
+		for range hupChan {
+			added, err := wlManager.Reload()
+			if err != nil {
+				utils.PrintError("Wordlist reload failed: %s", err)
+				continue
+			}
+			fmt.Println()
+			utils.PrintInfo("Wordlist reloaded: %d new entries", len(added))
+			if len(added) > 0 {
+				enginesMux.Lock()
+				// Later targets start from the reloaded list (a copy, the
+				// running engines append to their own)
+				config.Words = append(config.Words[:len(config.Words):len(config.Words)], added...)
+				for engine := range engines {
+					engine.AddWords(added)
+				}
+				enginesMux.Unlock()
+			}
+		}
 	}()
 
-	// Run
-	if err := engine.Run(); err != nil {
-		utils.PrintError("%s", err)
-		os.Exit(1)
+	// Periodic checkpoints of the output files
+	if *checkpoint > 0 {
+		stopCheckpoints := startCheckpoints(*checkpoint, checkpointWriters)
+		defer close(stopCheckpoints)
 	}
 
-	engine.PrintStats()
+	// Run each target with the same config, -hc of them at a time. In a
+	// batch, a target that fails (unresolvable, down, ...) is reported and
+	// skipped.
+	startTime := time.Now()
+	var statsMux sync.Mutex
+	var processed, found, errCount uint64
+	failed := 0
+	exitCode := 0
+	scanTarget := func(i int, target string) {
+		enginesMux.Lock()
+		if stopped {
+			enginesMux.Unlock()
+			return
+		}
+		cfg := *config
+		cfg.TargetURL = target
+		// Engines append to these (-fingerprint-ext, SIGHUP reloads): clipped,
+		// each append copies instead of writing into the shared array
+		cfg.Extensions = slices.Clip(cfg.Extensions)
+		cfg.Words = slices.Clip(cfg.Words)
+		engine := scanner.NewEngine(&cfg, writer)
+		engines[engine] = true
+		enginesMux.Unlock()
+		defer func() {
+			enginesMux.Lock()
+			delete(engines, engine)
+			enginesMux.Unlock()
+		}()
+
+		for _, sink := range sinks {
+			engine.AddSink(sink)
+		}
+		for _, out := range outputs {
+			engine.AddOutput(out)
+		}
+		if dbWriter != nil {
+			engine.AddSink(dbWriter.ForTarget(target))
+		}
+		if len(targets) > 1 {
+			fmt.Println(strings.Repeat("═", 70))
+			utils.PrintInfo("Target %d/%d: %s", i+1, len(targets), target)
+		}
+
+		if err := engine.Run(); err != nil {
+			if len(targets) > 1 {
+				utils.PrintError("Skipping %s: %s", target, err)
+				statsMux.Lock()
+				failed++
+				statsMux.Unlock()
+				return
+			}
+			// The only target: its findings so far are still saved, the
+			// exit code reports the failure once the outputs are closed
+			code := utils.ErrInternal
+			if errors.Is(err, scanner.ErrTargetDown) {
+				code = utils.ErrTarget
+			}
+			exitCode = utils.Report(code, "%s", err)
+		}
+
+		engine.PrintStats()
+		p, f, e := engine.Stats()
+		statsMux.Lock()
+		processed, found, errCount = processed+p, found+f, errCount+e
+		statsMux.Unlock()
+	}
+
+	hosts := make(chan int)
+	var hostsWg sync.WaitGroup
+	for n := 0; n < *hostConcurrency && n < len(targets); n++ {
+		hostsWg.Add(1)
+		go func() {
+			defer hostsWg.Done()
+			for i := range hosts {
+				scanTarget(i, targets[i])
+			}
+		}()
+	}
+	for i := range targets {
+		hosts <- i
+	}
+	close(hosts)
+	hostsWg.Wait()
+
+	if len(targets) > 1 {
+		fmt.Println(strings.Repeat("═", 70))
+		utils.PrintInfo("Batch: %d targets (%d failed) | Requests: %d | Found: %d | Errors: %d",
+			len(targets), failed, processed, found, errCount)
+	}
+
+	// Reproducibility record next to the output file
+	if *outputFile != "" {
+		manifestPath := *outputFile + ".manifest.json"
+		if err := writeManifest(manifestPath, targets, processed, found, errCount, wlManager, exts, startTime); err != nil {
+			utils.PrintError("Failed to write manifest: %s", err)
+		} else {
+			utils.PrintSuccess("Manifest: %s", manifestPath)
+		}
+	}
+	return exitCode
+}
+
+// hasBoolFlag reports whether a boolean flag is set on the command line
+func hasBoolFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		flagName, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if flagName != name {
+			continue
+		}
+		if !hasValue {
+			return true
+		}
+		enabled, _ := strconv.ParseBool(value)
+		return enabled
+	}
+	return false
+}
+
+// containsString reports whether list contains value
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// startCheckpoints saves the output writers every interval until the
+// returned channel is closed
+func startCheckpoints(interval time.Duration, writers []*output.Writer) chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				for _, w := range writers {
+					if err := w.Checkpoint(); err != nil {
+						utils.PrintError("Checkpoint of %s failed: %s", w.GetPath(), err)
+					}
+				}
+			}
+		}
+	}()
+	return done
+}
+
+// headerFlags collects the values of the repeatable -H flag
+type headerFlags []string
+
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headerFlags) Set(value string) error {
+	*h = append(*h, value)
+	return nil
+}
+
+// parseIntList parses a comma-separated list of integers, skipping invalid entries
+func parseIntList(value string) []int {
+	var list []int
+	if value == "" {
+		return list
+	}
+	for _, v := range strings.Split(value, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
+// parseList parses a comma-separated list, skipping empty entries
+func parseList(value string) []string {
+	var list []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// parseByteSize parses a byte count with an optional K, M or G suffix
+// (e.g. 500MB, 2G, 1048576); empty means 0
+func parseByteSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return 0, nil
+	}
+
+	multiplier := int64(1)
+	number := strings.TrimSuffix(value, "B")
+	for suffix, m := range map[string]int64{"K": 1 << 10, "M": 1 << 20, "G": 1 << 30} {
+		if strings.HasSuffix(number, suffix) {
+			number = strings.TrimSuffix(number, suffix)
+			multiplier = m
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q (expected e.g. 500MB, 2G or a byte count)", value)
+	}
+	return n * multiplier, nil
+}
+
+// parseDelay parses a duration or a "min-max" range such as 50-200ms, where
+// the min may omit the unit of the max; empty means no delay
+func parseDelay(value string) (min, max time.Duration, err error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, 0, nil
+	}
+
+	low, high, isRange := strings.Cut(value, "-")
+	if !isRange {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return 0, 0, fmt.Errorf("%q (expected e.g. 100ms or 50-200ms)", value)
+		}
+		return d, d, nil
+	}
+
+	max, err = time.ParseDuration(strings.TrimSpace(high))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q (expected e.g. 100ms or 50-200ms)", value)
+	}
+	low = strings.TrimSpace(low)
+	if min, err = time.ParseDuration(low); err != nil {
+		// 50-200ms: the min takes the unit of the max
+		unit := strings.TrimLeft(strings.TrimSpace(high), "0123456789.")
+		if min, err = time.ParseDuration(low + unit); err != nil {
+			return 0, 0, fmt.Errorf("%q (expected e.g. 100ms or 50-200ms)", value)
+		}
+	}
+	if min < 0 || min > max {
+		return 0, 0, fmt.Errorf("%q (min above max)", value)
+	}
+	return min, max, nil
+}
+
+// readTargets reads the target URLs of a -l file, one per line, skipping
+// blank lines, # comments and duplicates
+func readTargets(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read target list: %w", err)
+	}
+
+	var targets []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		targets = append(targets, line)
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets in %s", path)
+	}
+	return targets, nil
+}
+
+// parsePathDepths parses "prefix:depth" pairs such as "/api:20,/static:0"
+func parsePathDepths(value string) (map[string]int, error) {
+	depths := make(map[string]int)
+	if value == "" {
+		return depths, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		idx := strings.LastIndex(pair, ":")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid -d-path entry: %q (expected /path:depth)", pair)
+		}
+		depth, err := strconv.Atoi(pair[idx+1:])
+		if err != nil || depth < 0 {
+			return nil, fmt.Errorf("invalid depth in -d-path entry: %q", pair)
+		}
+		depths[pair[:idx]] = depth
+	}
+	return depths, nil
 }
 
 func printHelp() {
 	utils.Banner()
-	fmt.Println(`USAGE:
+	fmt.Printf(`USAGE:
   xsearch -u <url> [options]
 
 EXAMPLES:
@@ -190,21 +1141,231 @@ EXAMPLES:
   xsearch -u https://target.com -o results.txt     # Save results to file
   xsearch -u https://target.com -t 300             # Ultra-fast (300 threads)
   xsearch -u https://target.com -x php,html        # Custom extensions only
+  xsearch -u https://target.com -x-profile web,backup  # Extension bundles
   xsearch -u https://target.com -nr                # No recursion (fast scan)
   xsearch -u https://target.com -fc 403            # Hide 403 responses
+  xsearch -request req.txt -request-proto http     # Replay a raw request (FUZZ marker)
+  xsearch -merge a.json b.json -o all.txt          # Merge earlier JSON results
 
 OPTIONS:
-  -u <url>       Target URL (required)
-  -w <file>      Custom wordlist (auto-downloads if none)
-  -o <file>      Output file (URLs only, deduplicated)
+  -u <url>       Target URL (required unless -l)
+  -l <file>      Scan every target URL of file (one per line) in turn with the
+                 same options; failing targets are reported and skipped
+  -hc <n>        Scan n targets of -l in parallel (default: 1); together they
+                 never have more than -t requests in flight (each target
+                 also keeps idle connections pooled until its scan ends)
+  -w <files>     Custom wordlist, comma-separated to merge several
+                 (auto-downloads if none)
+  -stream        Stream the wordlist from disk instead of loading it into memory
+                 (for huge lists; the file is re-read by each scan phase)
+  -wl-stats      Print wordlist statistics (duplicates, dotted entries, ...)
+  -o <file>      Output file (URLs only, deduplicated); also writes
+                 <file>.manifest.json (version, flags, wordlist hash, counts)
+  -of <format>   Output format: tree, dirsearch, gobuster, json, json-compact
+                 (default: tree); json-compact keeps only {"u","s","z"}.
+                 With -l, dirsearch and gobuster lines carry the full URL
+  -write-codes <codes> Status codes written to -o, independent of what is
+                 displayed (default: 200,301,302,307,308,401,403)
+  -save-responses <dir> Save the body of every finding to dir (one file per
+                 URL, names sanitized) with manifest.json mapping files to
+                 URLs; bodies are capped at 512KB
+  -checkpoint <d> Save -o/-oJ/-oA every interval (e.g., 60s) so a crash or kill
+                 keeps the findings so far (tree files are rewritten)
+  -oJ <file>     Also save findings as JSON, one object per line (url, status,
+                 size, content_type, depth, is_dir, ...), whatever -o/-of is
+  -oA <base>     Also save findings to <base>.txt (gobuster lines), <base>.json
+                 (one object per line) and <base>.tree (tree) at once; they get
+                 the findings -o would (-write-codes, deduplicated, -confirm)
+  -o-graph <f>   Save paths as a JSON graph (nodes = paths, edges = containment)
+  -o-by-status <dir> Save findings to dir/200.txt, dir/403.txt, ... (format: -of)
+  -db <file>     Append findings to a SQLite database (findings table);
+                 requires a build with -tags sqlite
+  -syslog        Send each finding to syslog (severity by finding value)
+  -syslog-addr   Syslog server: udp://host:port or tcp://host:port (default: udp://127.0.0.1:514)
   -t <n>         Threads (default: 50)
-  -x <ext>       Extensions (default: 50+ extensions)
+  -retries <n>   Retry timeouts, connection resets and 502/503/504 up to n
+                 times with exponential backoff (250ms, 500ms, ...); only
+                 the final failure counts as an error (default: 0)
+  -rate <n>      Max requests per second across all threads (default: 0,
+                 unlimited); every request counts: HEAD probes, verification
+                 GETs, retries, calibration, redirects and post-scan checks
+  -threads-auto  Start at -threads-min and add threads while latency stays
+                 flat, backing off when it rises (replaces -t)
+  -threads-min <n> Lowest thread count with -threads-auto (default: 10)
+  -threads-max <n> Highest thread count with -threads-auto (default: 300)
+  -x <ext>       Extensions (default: 50+ extensions); wordlist entries with
+                 %%EXT%% (dirsearch style, e.g. index.%%EXT%%) take each one in place
+  -x-profile <p> Extension bundles, combinable with -x: web, php, dotnet, java,
+                 js, backup, config, archive, data
+  -prefix <list> Also try each word with these prefixes (e.g., dev-,old_ for
+                 dev-admin, old_config); the bare word is still tried
+  -suffix <list> Also try each word with these suffixes (e.g., -old,_bak for
+                 admin-old, config_bak); with %%EXT%% templates they go before
+                 the extension (index-old.php)
+  -mutate-case   Also try the lowercase, UPPERCASE and Title-case forms of each
+                 word, for case-sensitive servers (up to 4x the requests)
+  -dotfiles      Also probe hidden variants (.word, .word.ext) of each entry
+  -report-empty  Report 0-byte responses even when they match a soft-404
+                 baseline or fall under -min-confidence; -fc, -fs 0,
+                 -size-deviation, -mw, -fw and -fl still drop them
+  -stem-expand   Probe each file found with the other extensions of its
+                 -x-profile bundles (backup.zip -> backup.tar.gz, backup.7z)
+  -backup        Probe backup copies of each file found: .bak, .old, .orig,
+                 .save, ~, vim swap files and "Copy of" names
+  -follow        Follow redirects and show where each chain ends (JSON output
+                 records the whole chain); off by default, as directories are
+                 mostly told apart by their 301; hops to another host get
+                 no -H/-auth/-bearer/-ntlm credentials, -query parameters or
+                 -cache-bust parameter
+  -max-redirects <n> Hops followed with -follow (default: 10)
+  -encode-variants Also probe the percent-encoded and decoded forms of words with
+                 special characters (a?b -> a%%3Fb, %%2e%%2e -> ..)
+  -case-evade    Randomize the letter case of each probed word (aDmIn) to slip
+                 past case-sensitive WAF rules; ONLY works on case-insensitive
+                 servers (IIS, some configs), elsewhere every probe is a 404
+  -api-expand    Probe /v1, /v2, /v3 and /latest under api, rest and graphql
+                 directories; found versions join recursion and file discovery
+  -no-dot-skip   Probe dotted words (v1.2, api.v2) as directories too; by default
+                 they are treated as files and skipped in the directory phase
   -d <n>         Max recursion depth (default: 10)
+  -d-path <map>  Per-path depth overrides, longest prefix wins (e.g., /api:20,/static:0)
+  -cl-mismatch   Flag findings whose HEAD Content-Length differs from the body
+                 read by GET (>10%%): dynamic or chunked responses, odd servers
   -timeout <s>   Timeout in seconds (default: 10)
+  -no-keepalive  New connection per request (servers that answer wrongly on
+                 reused connections)
+  -idle-timeout <d> Close pooled connections idle this long (default: 120s)
+  -head-only     Never send verification GETs: roughly halves requests, but soft-404
+                 detection falls back to size only (no body hash), so expect more noise
+  -max-download <n> Read at most n bytes of bodies (e.g. 500MB, 2G), then
+                 continue HEAD-only for metered links; ignored (with a warning)
+                 by -mw, -fw, -fl, -contains, -soft404-require-hash,
+                 -report-hashes and -save-responses, which need every body
+  -query <q>     Query string added to every request, e.g. "apikey=X&v=2"
+                 (parameters already in the URL take precedence)
+  -random-agent  Send a random browser User-Agent (Chrome, Firefox, Safari,
+                 Edge; desktop and mobile) per request; HEAD and its
+                 verification GET share one
+  -cache-bust    Add a random _=<hex> parameter to every request to bypass
+                 caches; 200s that 404 without it are dropped
+  -ntlm <creds>  NTLM/Negotiate auth for IIS/intranet targets: user:pass,
+                 DOMAIN\user:pass or user@domain:pass (built-in NTLMv2, no extra deps)
+  -proxy <url>   Route requests through a proxy: http://host:port (Burp),
+                 https://, socks5:// or socks5h:// (remote DNS); user:pass@ ok
+  -auth <u:p>    HTTP basic auth for every request, calibration included
+  -bearer <tok>  Send "Authorization: Bearer <tok>" with every request,
+                 calibration included (conflicts with -auth)
+  -H <header>    Send a custom header, "Name: Value" (repeatable); overrides
+                 the default User-Agent and Accept headers
+  -cookie-jar <f> Send cookies from a Netscape cookies.txt file (browser or
+                 curl -c export), scoped by domain, path and secure flag
+  -merge <files> Merge JSON findings files (-of json or json-compact) into one
+                 deduplicated report; combine with -o/-of, no scan is run
+  -request <f>   Raw HTTP request file (e.g. from Burp) with FUZZ marker; the
+                 target is its Host, so -u and -l are not allowed
+  -request-proto Scheme for -request: http or https (default: https)
+  -X <method>    Probe with this method in one request instead of HEAD then GET,
+                 e.g. PROPFIND for WebDAV or GET for APIs that 405 on HEAD;
+                 calibration and -confirm/-cache-bust re-checks use it too
+  -data <body>   Form body sent with -X (implies -X POST)
+  -max-errors <n> Stop or pause after n consecutive errors (default: off)
+  -cooldown <d>  Pause duration when -max-errors trips, e.g. 30s (default: abort)
+  -replay-delay <d> On 429/503 slow to one request per delay (e.g. 5s), single
+                 thread, then speed back up as clean responses resume
+  -delay <d>     Each worker waits d after every request (e.g. 100ms), or a
+                 random time within a range for jitter (e.g. 50-200ms)
+  -enum-methods  Report allowed methods (OPTIONS Allow header) per finding
+  -fingerprint   Detect server/framework (headers, cookies, known paths, favicon)
+  -fingerprint-ext Fingerprint, then add extensions for the detected stack
+  -favicon-hash  Print the /favicon.ico hash in Shodan format (http.favicon.hash)
+                 and the product it belongs to (Jenkins, Tomcat, GitLab, ...)
   -nr            Disable recursive scanning
+  -scan-root-first Report the base URL first to confirm the target is up
+                 (default: on, disable with -scan-root-first=false)
+  -recurse-skip-ext <ext> Skip recursion and file scans in directories
+                 dominated by these extensions (e.g., png,jpg,css,woff), judged
+                 by their name (images, css, fonts...) and listing
+  -recurse-min-findings <n> Recurse only into directories discovered where at
+                 least n findings were made (prunes empty/catch-all trees)
+  -max-dirs <n>  Recurse into and scan files in at most n directories,
+                 shallowest first (warns when coverage is truncated)
+  -maxreq <n>    Hard cap: stop the scan after n probed URLs (per target with
+                 -l) and report it as truncated; counts like the Requests stat,
+                 one per URL, not the HEAD+GET pair, retries, calibration or
+                 post-scan checks (use -rate to bound those)
+  -unified       Scan directories and files in one pass: each directory
+                 queues its subdirectories and files as soon as it is found,
+                 so file findings arrive early (-max-dirs then keeps the
+                 first n directories found)
   -fc <codes>    Filter status codes (e.g., 403,500)
+  -dir-codes <codes> Status codes that mean a directory and are recursed
+                 into, e.g. 301,403 (default: 200,301,302,307,308); other
+                 codes are always reported as files
+  -rc <codes>    Recurse only into directories answering these codes, e.g.
+                 200 to skip 403 and redirect mazes (default: -dir-codes,
+                 or 200,301,302,307,308); unlike -dir-codes it leaves the
+                 file phase alone: the other directories are still scanned
+                 for files
+  -mc <codes>    Show only these status codes (e.g., 200,301), 5xx and 404
+                 included when listed; -fc still removes codes from the
+                 matched set; directories shown or not are still recursed into
   -fs <sizes>    Filter by size (e.g., 0,1234)
+  -recurse-only <re> Recurse only into directories whose name matches re
+                 (e.g., 'api|admin|v[0-9]'); other directories still get
+                 the file phase
+  -filter-regex-url <re> Never request candidate URLs matching re (e.g., '\.(png|jpg|css)$')
+  -cal-method <m> Calibration method, e.g. HEAD to match HEAD-only scans (default: GET);
+                 not allowed with -X, which calibration follows
+  -well-known    Probe standard /.well-known/ resources before Phase 1:
+                 security.txt, openid-configuration, jwks.json,
+                 apple-app-site-association, assetlinks.json, change-password...
+  -robots        Probe the Allow/Disallow paths of robots.txt before Phase 1
+                 (or the -unified pass); directories found there are scanned
+                 and recursed into
+  -passive       Low-noise discovery without brute force: read robots.txt,
+                 sitemaps, security.txt and homepage links, then check each
+                 path found once (no wordlist needed)
+  -calibrate-only Show how the target answers missing paths (status, size, hash,
+                 GET vs HEAD, soft-404 kind) and exit without scanning
+  -cal-samples <n> Missing paths requested by -calibrate-only (default: 10)
+  -soft404-require-hash Only treat body hash matches as soft-404 (no size-only
+                 matches); always reads bodies
+  -exclude-homepage Drop findings whose body matches the homepage
+  -confirm       Re-request every finding after the scan and keep only those
+                 still reliable in the output file (fewer false positives)
+  -size-deviation <pct> Only report sizes more than pct%% away from the
+                 calibration baseline size (e.g., 20)
+  -min-confidence <n> Hide findings scoring below n (0-1); the score combines
+                 status, size vs baseline, body hash uniqueness, content type
+  -mw <counts>   Show only responses with these body word counts (e.g., 42)
+  -fw <counts>   Filter responses with these body word counts (e.g., 42);
+                 a count given to both -mw and -fw is filtered
+  -fl <counts>   Filter responses with these body line counts (e.g., 12),
+                 for templated error pages whose size varies
+  -mct <types>   Show only Content-Types containing one of these, any case
+                 (e.g., json or application/json,xml)
+  -fct <types>   Filter Content-Types containing one of these (e.g., image/);
+                 like -mct it only hides findings, directories are still scanned
+  -contains <s>  Show only responses whose body contains s (e.g., password)
+  -contains-i    Match -contains case-insensitively
   -q             Quiet mode (no banner)
+  -progress-interval <d> Progress refresh interval, e.g. 100ms or 10s
+                 (default: 500ms, 0 disables progress)
+  -timing-stats  Report response time percentiles (p50/p90/p99) at the end,
+                 to tune -timeout and -t for the host
+  -verbose       Print the first 10 request errors as they happen (by default
+                 they are only counted and summarized at the end)
+  -quiet-errors  Never print request errors, even with -verbose
+  -json-errors   Report fatal errors as {"error","code"} JSON on stderr; exit codes:
+                 2 config, 3 wordlist, 4 target down, 5 output, 1 other
+  -color <mode>  Color output: auto (TTY only), always, never (default: auto)
+  -rules <file>  Tag findings with rules like "*.sql => db-backup", "admin* 200 => admin"
+  -report-hashes <file> Always report bodies with these MD5s (md5sum format),
+                 bypassing filters and soft-404 checks (not 404s)
+  -source        Show discovery source (wordlist, robots, ...) per finding
+  -stdout-format <tmpl> Custom terminal line, e.g. "{status} {size} {url}"; tokens:
+                 {status} {size} {url} {path} {type} {depth} {content-type}
+                 {duration} {redirect} {confidence}
   -v             Version
   -h             Help
   -up            Auto-upgrade from GitHub
@@ -222,11 +1383,103 @@ EXTENSIONS (50+):
   Backup:   bak old sql log zip tar gz
   Special:  git svn DS_Store
 
+SIGNALS:
+  SIGHUP         Reload the wordlist and queue new entries mid-scan
+
 OPTIMIZATIONS:
   - HEAD requests for speed
   - Dynamic soft-404 filtering
   - Connection pooling + HTTP/2
-  - Real-time progress bar`)
+  - Real-time progress bar
+`)
+}
+
+// Manifest records how a scan was run, written next to the output file
+type Manifest struct {
+	Version      string            `json:"version"`
+	Target       string            `json:"target"`
+	Targets      []string          `json:"targets,omitempty"` // The -l batch
+	Started      time.Time         `json:"started"`
+	Finished     time.Time         `json:"finished"`
+	Args         []string          `json:"args"`
+	Flags        map[string]string `json:"flags"` // Every flag with its resolved value, credentials redacted
+	Extensions   []string          `json:"extensions"`
+	Wordlist     string            `json:"wordlist"`
+	WordlistHash string            `json:"wordlist_sha256"`
+	Requests     uint64            `json:"requests"`
+	Found        uint64            `json:"found"`
+	Errors       uint64            `json:"errors"`
+}
+
+// sensitiveFlags hold credentials and are redacted from the manifest
+var sensitiveFlags = map[string]bool{
+	"ntlm":   true,
+	"H":      true,
+	"proxy":  true,
+	"auth":   true,
+	"bearer": true,
+	"query":  true, // API keys, e.g. apikey=X
+}
+
+// redactArgs masks the values of sensitive flags in a command line
+func redactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+	for i, arg := range redacted {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || !sensitiveFlags[name] {
+			continue
+		}
+		if hasValue {
+			redacted[i] = arg[:strings.Index(arg, "=")+1] + "REDACTED"
+		} else if i+1 < len(redacted) {
+			redacted[i+1] = "REDACTED"
+		}
+	}
+	return redacted
+}
+
+// writeManifest saves the scan manifest as JSON, with the totals of all targets
+func writeManifest(path string, targets []string, requests, found, errors uint64, wl *wordlist.Manager, exts []string, started time.Time) error {
+	flags := make(map[string]string)
+	flag.VisitAll(func(f *flag.Flag) {
+		flags[f.Name] = f.Value.String()
+		if sensitiveFlags[f.Name] && flags[f.Name] != "" {
+			flags[f.Name] = "REDACTED"
+		}
+	})
+
+	m := Manifest{
+		Version:    version,
+		Target:     flags["u"],
+		Started:    started,
+		Finished:   time.Now(),
+		Args:       redactArgs(os.Args[1:]),
+		Flags:      flags,
+		Extensions: exts,
+		Requests:   requests,
+		Found:      found,
+		Errors:     errors,
+	}
+	if len(targets) > 1 {
+		m.Targets = targets
+	}
+
+	// Passive scans use no wordlist
+	if wl != nil {
+		hash, err := wl.Hash()
+		if err != nil {
+			return err
+		}
+		m.Wordlist = wl.GetPath()
+		m.WordlistHash = hash
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
 }
 
 // GitHubRelease represents a GitHub release
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// runArgs runs the command line with args and returns its exit code
+func runArgs(t *testing.T, args ...string) int {
+	t.Helper()
+	oldArgs, oldFlags := os.Args, flag.CommandLine
+	defer func() { os.Args, flag.CommandLine = oldArgs, oldFlags }()
+
+	os.Args = append([]string{"xsearch"}, args...)
+	flag.CommandLine = flag.NewFlagSet("xsearch", flag.ContinueOnError)
+	return run()
+}
+
+func TestOutputSavedWhenBreakerTrips(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/" || r.URL.Path == "/admin" || r.URL.Path == "/admin/":
+			w.Write([]byte("admin panel"))
+		case strings.HasPrefix(r.URL.Path, "/down"):
+			// Drop the connection: a request error for the breaker
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	words := []string{"admin"}
+	for i := 0; i < 20; i++ {
+		words = append(words, "down"+strings.Repeat("x", i))
+	}
+	wordlist := filepath.Join(dir, "words.txt")
+	if err := os.WriteFile(wordlist, []byte(strings.Join(words, "\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out.txt")
+
+	code := runArgs(t, "-u", srv.URL, "-w", wordlist, "-t", "1", "-max-errors", "5",
+		"-o", out, "-q", "-color", "never", "-progress-interval", "0")
+	if code == 0 {
+		t.Fatal("exit code = 0, want non-zero after the breaker tripped")
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "/admin") {
+		t.Errorf("-o file is missing /admin:\n%s", data)
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"separate value", []string{"-u", "http://t", "-bearer", "tok"}, []string{"-u", "http://t", "-bearer", "REDACTED"}},
+		{"inline value", []string{"--auth=user:pass"}, []string{"--auth=REDACTED"}},
+		{"query", []string{"-query", "apikey=X&v=2", "-t", "10"}, []string{"-query", "REDACTED", "-t", "10"}},
+		{"query inline", []string{"-query=apikey=X"}, []string{"-query=REDACTED"}},
+		{"not sensitive", []string{"-w", "words.txt"}, []string{"-w", "words.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactArgs(tt.args); !slices.Equal(got, tt.want) {
+				t.Errorf("redactArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/output"
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// mergeFiles separates the findings files of -merge from flags given after
+// them (e.g. "-merge a.json b.json -o out.txt"), parsing those flags too
+func mergeFiles(args []string) ([]string, error) {
+	var files []string
+	for len(args) > 0 {
+		if !strings.HasPrefix(args[0], "-") {
+			files = append(files, args[0])
+			args = args[1:]
+			continue
+		}
+		if err := flag.CommandLine.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flag.Args()
+	}
+	return files, nil
+}
+
+// runMerge combines findings files into one deduplicated report, printed
+// and written to outputFile in the given format
+func runMerge(files []string, outputFile string, format string) error {
+	if len(files) == 0 {
+		return fmt.Errorf("-merge needs at least one findings file (JSON from -of json)")
+	}
+
+	var sets [][]output.Finding
+	total := 0
+	for _, path := range files {
+		findings, err := output.ReadFindings(path)
+		if err != nil {
+			return err
+		}
+		utils.PrintInfo("Loaded %d findings from %s", len(findings), path)
+		sets = append(sets, findings)
+		total += len(findings)
+	}
+	merged := output.MergeFindings(sets...)
+
+	writer, err := output.NewWriter(outputFile, format)
+	if err != nil {
+		return err
+	}
+
+	printer := output.NewPrinter(nil)
+	for i := range merged {
+		printer.PrintResult(&merged[i])
+		writer.WriteFinding(&merged[i])
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	utils.PrintSuccess("Merged %d findings into %d unique URLs", total, len(merged))
+	if writer.IsEnabled() {
+		utils.PrintSuccess("Saved to: %s", writer.GetPath())
+	}
+	return nil
+}
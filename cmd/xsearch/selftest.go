@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Fastdev75/xsearch/internal/output"
+	"github.com/Fastdev75/xsearch/internal/scanner"
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// selfTestPages are the real paths served by the self-test server. Anything
+// else gets a soft 404 (200 with a constant "not found" page).
+var selfTestPages = map[string]string{
+	"/":                  "<html>self-test home</html>",
+	"/admin/":            "<html>admin panel</html>",
+	"/admin/login.php":   "<form>login</form>",
+	"/admin/users/":      "<html>user list</html>",
+	"/api/":              `{"status":"ok"}`,
+	"/api/v1/":           `{"version":1}`,
+	"/backup.zip":        "PK\x03\x04 backup",
+	"/config.bak":        "<?php $db = 'secret';",
+	"/images/":           "<html>images</html>",
+	"/images/logo.png":   "\x89PNG logo",
+	"/uploads/":          "<html>uploads</html>",
+	"/uploads/shell.php": "<?php system($_GET['c']);",
+}
+
+// selfTestWords mixes real entries with words that only hit the soft 404
+var selfTestWords = []string{
+	"admin", "api", "backup", "config", "images", "uploads", "login", "users",
+	"v1", "logo", "shell", "missing", "test", "old", "private", "secret",
+	"static", "assets", "css", "js", "tmp", "dev", "staging", "data",
+}
+
+// collector is a Sink that keeps every finding in memory
+type collector struct {
+	mu       sync.Mutex
+	findings []output.Finding
+}
+
+func (c *collector) WriteFinding(f *output.Finding) error {
+	c.mu.Lock()
+	c.findings = append(c.findings, *f)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *collector) Close() error {
+	return nil
+}
+
+// runSelfTest scans an in-process server with known content and soft-404
+// behavior, then checks the findings. Returns false if any check failed.
+func runSelfTest(threads int) bool {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if body, ok := selfTestPages[path]; ok {
+			w.Write([]byte(body))
+			return
+		}
+		if _, ok := selfTestPages[path+"/"]; ok {
+			http.Redirect(w, r, path+"/", http.StatusMovedPermanently)
+			return
+		}
+		// Soft 404: success status with the same error page for every miss
+		w.Write([]byte("<html><h1>Oops</h1>The page you requested could not be found.</html>"))
+	}))
+	defer server.Close()
+
+	utils.PrintInfo("Self-test server: %s", server.URL)
+
+	writer, err := output.NewWriter("", output.FormatTree)
+	if err != nil {
+		utils.PrintError("%s", err)
+		return false
+	}
+
+	config := &scanner.Config{
+		TargetURL:  server.URL,
+		Words:      selfTestWords,
+		Threads:    threads,
+		Timeout:    5 * time.Second,
+		UserAgent:  "xsearch-self-test",
+		Extensions: []string{"php", "zip", "bak", "png"},
+		Recursive:  true,
+		MaxDepth:   3,
+		AddSlash:   true,
+	}
+
+	engine := scanner.NewEngine(config, writer)
+	sink := &collector{}
+	engine.AddSink(sink)
+
+	start := time.Now()
+	if err := engine.Run(); err != nil {
+		utils.PrintError("%s", err)
+		return false
+	}
+	elapsed := time.Since(start)
+	engine.PrintStats()
+
+	found := make(map[string]bool)
+	for _, f := range sink.findings {
+		found[strings.TrimPrefix(f.URL, server.URL)] = true
+	}
+
+	ok := true
+
+	// Every real page except the homepage must be discovered
+	var expected []string
+	for path := range selfTestPages {
+		if path != "/" {
+			expected = append(expected, path)
+		}
+	}
+	sort.Strings(expected)
+	for _, path := range expected {
+		if !found[path] {
+			utils.PrintError("Missing: %s", path)
+			ok = false
+		}
+	}
+
+	// Anything else (except directory redirects) is an unsuppressed soft 404
+	var unexpected []string
+	for path := range found {
+		if _, real := selfTestPages[path]; real {
+			continue
+		}
+		if _, redirect := selfTestPages[path+"/"]; redirect {
+			continue
+		}
+		unexpected = append(unexpected, path)
+	}
+	sort.Strings(unexpected)
+	for _, path := range unexpected {
+		utils.PrintError("Soft 404 not suppressed: %s", path)
+		ok = false
+	}
+
+	processed, _, _ := engine.Stats()
+	fmt.Println(strings.Repeat("─", 70))
+	utils.PrintInfo("Throughput: %d requests in %s (%.0f req/s)", processed, elapsed.Round(time.Millisecond), float64(processed)/elapsed.Seconds())
+
+	if ok {
+		utils.PrintSuccess("Self-test passed: %d/%d paths found, no false positives", len(expected), len(expected))
+	} else {
+		utils.PrintError("Self-test failed")
+	}
+	return ok
+}
@@ -0,0 +1,38 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+)
+
+// CacheBustParam is the query parameter carrying the random cache buster
+const CacheBustParam = "_"
+
+type noCacheBustKey struct{}
+
+// WithoutCacheBust returns req marked to be sent without the cache buster
+func WithoutCacheBust(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), noCacheBustKey{}, true))
+}
+
+// cacheBustTransport appends a random query parameter to every request so
+// caches and CDNs in front of the target never answer from a stale entry (hops
+// redirected to another host are sent as they are)
+type cacheBustTransport struct {
+	base http.RoundTripper
+}
+
+func (t *cacheBustTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if skip, _ := req.Context().Value(noCacheBustKey{}).(bool); skip || redirectedAway(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	var buf [4]byte
+	rand.Read(buf[:])
+	req = req.Clone(req.Context())
+	req.URL.RawQuery = MergeQuery(req.URL.RawQuery, url.Values{CacheBustParam: {hex.EncodeToString(buf[:])}})
+	return t.base.RoundTrip(req)
+}
@@ -1,12 +1,17 @@
 package httpclient
 
 import (
+	"bytes"
 	"crypto/md5"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -14,7 +19,17 @@ import (
 type Config struct {
 	Timeout         time.Duration
 	FollowRedirects bool
+	MaxRedirects    int // Hops followed with FollowRedirects (0 = 10)
 	UserAgent       string
+	Query           url.Values       // Appended to every request URL (existing keys win)
+	NTLM            *NTLMCredentials // Answer NTLM/Negotiate challenges with these credentials
+	NoKeepAlive     bool             // Fresh connection per request (servers that corrupt pooled connections)
+	IdleTimeout     time.Duration    // How long idle pooled connections are kept (0 = 120s)
+	CacheBust       bool             // Append a random query parameter to every request
+	Jar             http.CookieJar   // Cookies sent by domain and path (-cookie-jar)
+	Headers         http.Header      // Set on every request, overriding the defaults (-H)
+	Proxy           *url.URL         // HTTP(S) or SOCKS5 proxy for every request (see ParseProxy)
+	Gate            Gate             // Called before each request goes on the wire (rate limits, budgets)
 }
 
 // DefaultConfig returns a default HTTP client configuration
@@ -32,6 +47,11 @@ func NewClient(cfg *Config) *http.Client {
 		cfg = DefaultConfig()
 	}
 
+	idleTimeout := 120 * time.Second
+	if cfg.IdleTimeout > 0 {
+		idleTimeout = cfg.IdleTimeout
+	}
+
 	transport := &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: true,
@@ -42,23 +62,47 @@ func NewClient(cfg *Config) *http.Client {
 			KeepAlive: 60 * time.Second, // Increased for better connection reuse
 		}).DialContext,
 		// Optimized connection pool settings
-		MaxIdleConns:          500,  // Increased from 200
-		MaxIdleConnsPerHost:   200,  // Increased from 100
-		MaxConnsPerHost:       200,  // Increased from 100
-		IdleConnTimeout:       120 * time.Second,
+		MaxIdleConns:          500, // Increased from 200
+		MaxIdleConnsPerHost:   200, // Increased from 100
+		MaxConnsPerHost:       200, // Increased from 100
+		IdleConnTimeout:       idleTimeout,
 		TLSHandshakeTimeout:   5 * time.Second, // Reduced from 10s
 		ExpectContinueTimeout: 1 * time.Second,
-		DisableKeepAlives:     false,
+		DisableKeepAlives:     cfg.NoKeepAlive,
 		DisableCompression:    true, // Disable for speed (we don't need to decompress)
 		ForceAttemptHTTP2:     true,
 		ResponseHeaderTimeout: cfg.Timeout,
 		WriteBufferSize:       4096,  // Optimized buffer
 		ReadBufferSize:        16384, // Optimized buffer for reading
 	}
+	if cfg.Proxy != nil {
+		// net/http dials socks5:// proxies itself
+		transport.Proxy = http.ProxyURL(cfg.Proxy)
+	}
 
 	client := &http.Client{
 		Transport: transport,
 		Timeout:   cfg.Timeout,
+		Jar:       cfg.Jar,
+	}
+	if cfg.Gate != nil {
+		// Innermost, so every message on the wire passes it
+		client.Transport = &gateTransport{base: client.Transport, gate: cfg.Gate}
+	}
+	if cfg.NoKeepAlive {
+		client.Transport = &closeTransport{base: client.Transport}
+	}
+	if len(cfg.Headers) > 0 {
+		client.Transport = &headerTransport{base: client.Transport, headers: cfg.Headers}
+	}
+	if cfg.NTLM != nil {
+		client.Transport = &ntlmTransport{base: client.Transport, creds: cfg.NTLM}
+	}
+	if len(cfg.Query) > 0 {
+		client.Transport = &queryTransport{base: client.Transport, query: cfg.Query}
+	}
+	if cfg.CacheBust {
+		client.Transport = &cacheBustTransport{base: client.Transport}
 	}
 
 	// Disable redirect following for directory detection
@@ -66,20 +110,110 @@ func NewClient(cfg *Config) *http.Client {
 		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		}
+	} else {
+		maxRedirects := 10
+		if cfg.MaxRedirects > 0 {
+			maxRedirects = cfg.MaxRedirects
+		}
+		// Stop on A->B->A loops instead of bouncing until the hop limit
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			for _, prev := range via {
+				if prev.URL.String() == req.URL.String() {
+					return ErrRedirectLoop
+				}
+			}
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return nil
+		}
 	}
 
 	return client
 }
 
+// closeTransport drops the "Connection: keep-alive" header the request helpers
+// set, so the server sees only the "Connection: close" of DisableKeepAlives
+type closeTransport struct {
+	base http.RoundTripper
+}
+
+func (t *closeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Del("Connection")
+	req.Close = true
+	return t.base.RoundTrip(req)
+}
+
+// queryTransport appends fixed query parameters to every outgoing request
+type queryTransport struct {
+	base  http.RoundTripper
+	query url.Values
+}
+
+func (t *queryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The parameters often carry credentials: keep them off other hosts
+	if redirectedAway(req) {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.URL.RawQuery = MergeQuery(req.URL.RawQuery, t.query)
+	return t.base.RoundTrip(req)
+}
+
+// MergeQuery appends the parameters of extra that rawQuery does not already
+// set. The existing query is kept verbatim so its encoding is not altered.
+func MergeQuery(rawQuery string, extra url.Values) string {
+	existing, _ := url.ParseQuery(rawQuery)
+	add := make(url.Values)
+	for key, values := range extra {
+		if _, ok := existing[key]; !ok {
+			add[key] = values
+		}
+	}
+	if len(add) == 0 {
+		return rawQuery
+	}
+	if rawQuery == "" {
+		return add.Encode()
+	}
+	return rawQuery + "&" + add.Encode()
+}
+
+// redirectChain lists the URLs requested to reach final, following the
+// redirect responses that created each request
+func redirectChain(final *http.Request) []string {
+	var chain []string
+	for req := final; req != nil; {
+		chain = append(chain, req.URL.String())
+		if req.Response == nil {
+			break
+		}
+		req = req.Response.Request
+	}
+	slices.Reverse(chain)
+	return chain
+}
+
+// ErrRedirectLoop is returned by CheckRedirect when a redirect chain revisits a URL
+var ErrRedirectLoop = errors.New("redirect loop")
+
 // Result holds the HTTP request result
 type Result struct {
-	URL         string
-	StatusCode  int
-	Size        int64
-	BodyHash    string
-	ContentType string
-	RedirectURL string
-	Error       error
+	URL           string
+	StatusCode    int
+	Size          int64
+	BodyHash      string
+	Words         int    // Word count of the body read, if any
+	Lines         int    // Line count of the body read, if any
+	Body          []byte // The body read, if any (truncated to the read limit)
+	ContentType   string
+	RedirectURL   string
+	RedirectLoop  bool          // Redirects to itself, or the followed chain loops
+	RedirectChain []string      // URLs of a followed chain, the request first and the final URL last
+	Duration      time.Duration // Time until the response headers arrived
+	Header        http.Header
+	Error         error
 }
 
 // Request performs an HTTP GET request and returns the result (headers only)
@@ -94,12 +228,9 @@ func RequestWithBody(client *http.Client, url string, userAgent string) *Result
 
 // request is the internal request function
 func request(client *http.Client, url string, userAgent string, readBody bool) *Result {
-	result := &Result{URL: url}
-
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		result.Error = err
-		return result
+		return &Result{URL: url, Error: err}
 	}
 
 	// Minimal headers for speed
@@ -107,7 +238,28 @@ func request(client *http.Client, url string, userAgent string, readBody bool) *
 	req.Header.Set("Accept", "*/*")
 	req.Header.Set("Connection", "keep-alive")
 
+	result := Send(client, req, readBody)
+	result.URL = url
+	return result
+}
+
+// Send performs an arbitrary prepared request and collects the result.
+// HEAD requests never read a body; other methods read it when readBody is set,
+// otherwise the size falls back to Content-Length.
+func Send(client *http.Client, req *http.Request, readBody bool) *Result {
+	result := &Result{URL: req.URL.String()}
+
+	start := time.Now()
 	resp, err := client.Do(req)
+	result.Duration = time.Since(start)
+	if err != nil && errors.Is(err, ErrRedirectLoop) && resp != nil {
+		// The loop's last response is returned with its body already closed
+		result.StatusCode = resp.StatusCode
+		result.RedirectURL = resolveLocation(resp.Request.URL, resp.Header.Get("Location"))
+		result.RedirectLoop = true
+		result.Header = resp.Header
+		return result
+	}
 	if err != nil {
 		result.Error = err
 		return result
@@ -116,10 +268,23 @@ func request(client *http.Client, url string, userAgent string, readBody bool) *
 
 	result.StatusCode = resp.StatusCode
 	result.ContentType = resp.Header.Get("Content-Type")
+	result.Header = resp.Header
+
+	// Chain of followed redirects, ending at the URL that answered
+	if resp.Request.Response != nil {
+		result.RedirectChain = redirectChain(resp.Request)
+		result.RedirectURL = resp.Request.URL.String()
+	}
 
 	// Get redirect URL if applicable
 	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
-		result.RedirectURL = resp.Header.Get("Location")
+		result.RedirectURL = resolveLocation(resp.Request.URL, resp.Header.Get("Location"))
+		result.RedirectLoop = isSelfRedirect(resp.Request.URL, result.RedirectURL)
+	}
+
+	if req.Method == "HEAD" {
+		result.Size = resp.ContentLength
+		return result
 	}
 
 	if readBody {
@@ -132,6 +297,9 @@ func request(client *http.Client, url string, userAgent string, readBody bool) *
 		}
 		result.Size = int64(len(body))
 		result.BodyHash = fmt.Sprintf("%x", md5.Sum(body))
+		result.Words = len(bytes.Fields(body))
+		result.Lines = countLines(body)
+		result.Body = body
 	} else {
 		// Just use Content-Length header
 		result.Size = resp.ContentLength
@@ -141,6 +309,9 @@ func request(client *http.Client, url string, userAgent string, readBody bool) *
 			if err == nil {
 				result.Size = int64(len(body))
 				result.BodyHash = fmt.Sprintf("%x", md5.Sum(body))
+				result.Words = len(bytes.Fields(body))
+				result.Lines = countLines(body)
+				result.Body = body
 			}
 		}
 	}
@@ -148,35 +319,100 @@ func request(client *http.Client, url string, userAgent string, readBody bool) *
 	return result
 }
 
-// HeadRequest performs an HTTP HEAD request (much faster, no body transfer)
-func HeadRequest(client *http.Client, url string, userAgent string) *Result {
-	result := &Result{URL: url}
+// countLines counts the lines of a body the way ffuf does (newlines + 1)
+func countLines(body []byte) int {
+	return bytes.Count(body, []byte("\n")) + 1
+}
 
-	req, err := http.NewRequest("HEAD", url, nil)
+// resolveLocation makes a Location header absolute: relative (login) and
+// root-relative (/login) values are resolved against the request URL.
+// Unparseable values are returned unchanged.
+func resolveLocation(requestURL *url.URL, location string) string {
+	if location == "" || requestURL == nil {
+		return location
+	}
+	target, err := url.Parse(location)
 	if err != nil {
-		result.Error = err
-		return result
+		return location
+	}
+	return requestURL.ResolveReference(target).String()
+}
+
+// isSelfRedirect reports whether a Location header points back at the request URL
+func isSelfRedirect(requestURL *url.URL, location string) bool {
+	if location == "" {
+		return false
+	}
+	target, err := requestURL.Parse(location)
+	if err != nil {
+		return false
+	}
+	return target.String() == requestURL.String()
+}
+
+// MethodRequest performs a bodiless request with an arbitrary HTTP method
+func MethodRequest(client *http.Client, method string, url string, userAgent string) *Result {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return &Result{URL: url, Error: err}
 	}
 
-	// Minimal headers for speed
 	req.Header.Set("User-Agent", userAgent)
 	req.Header.Set("Accept", "*/*")
 	req.Header.Set("Connection", "keep-alive")
 
-	resp, err := client.Do(req)
+	result := Send(client, req, false)
+	result.URL = url
+	return result
+}
+
+// DataRequest performs a request with an arbitrary HTTP method and an
+// optional form body (-X/-data), reading the response body when readBody is set
+func DataRequest(client *http.Client, method string, url string, userAgent string, data string, readBody bool) *Result {
+	req, err := NewDataRequest(method, url, userAgent, data)
 	if err != nil {
-		result.Error = err
-		return result
+		return &Result{URL: url, Error: err}
 	}
-	defer resp.Body.Close()
 
-	result.StatusCode = resp.StatusCode
-	result.Size = resp.ContentLength
-	result.ContentType = resp.Header.Get("Content-Type")
+	result := Send(client, req, readBody)
+	result.URL = url
+	return result
+}
 
-	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
-		result.RedirectURL = resp.Header.Get("Location")
+// NewDataRequest builds the request sent by DataRequest, for callers that
+// need to adjust it before sending
+func NewDataRequest(method string, url string, userAgent string, data string) (*http.Request, error) {
+	var body io.Reader
+	if data != "" {
+		body = strings.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Connection", "keep-alive")
+	if data != "" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	}
+	return req, nil
+}
+
+// HeadRequest performs an HTTP HEAD request (much faster, no body transfer)
+func HeadRequest(client *http.Client, url string, userAgent string) *Result {
+	req, err := http.NewRequest("HEAD", url, nil)
+	if err != nil {
+		return &Result{URL: url, Error: err}
+	}
+
+	// Minimal headers for speed
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Connection", "keep-alive")
 
+	result := Send(client, req, false)
+	result.URL = url
 	return result
 }
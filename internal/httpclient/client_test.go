@@ -0,0 +1,84 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestRedirectURLIsAbsolute(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", r.URL.Query().Get("to"))
+		w.WriteHeader(http.StatusMovedPermanently)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		location string
+		want     string
+	}{
+		{"relative", "login", srv.URL + "/app/login"},
+		{"relative parent", "../login", srv.URL + "/login"},
+		{"root-relative", "/login", srv.URL + "/login"},
+		{"absolute", "https://other.example/login", "https://other.example/login"},
+	}
+
+	client := NewClient(DefaultConfig())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Request(client, srv.URL+"/app/old?to="+tt.location, "test")
+			if r.Error != nil {
+				t.Fatal(r.Error)
+			}
+			if r.StatusCode != http.StatusMovedPermanently {
+				t.Fatalf("status = %d, want 301", r.StatusCode)
+			}
+			if r.RedirectURL != tt.want {
+				t.Errorf("RedirectURL = %q, want %q", r.RedirectURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrossHostRedirectKeepsQueryAway(t *testing.T) {
+	var got url.Values
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+	}))
+	defer other.Close()
+
+	var sameHost url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/away":
+			http.Redirect(w, r, other.URL+"/landing", http.StatusFound)
+		case "/here":
+			http.Redirect(w, r, "/landing", http.StatusFound)
+		default:
+			sameHost = r.URL.Query()
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(&Config{
+		FollowRedirects: true,
+		Query:           url.Values{"apikey": {"secret"}},
+		CacheBust:       true,
+	})
+
+	if r := Request(client, srv.URL+"/away", "test"); r.Error != nil {
+		t.Fatal(r.Error)
+	}
+	if got.Has("apikey") || got.Has(CacheBustParam) {
+		t.Errorf("other host got query %v, want neither apikey nor %s", got, CacheBustParam)
+	}
+
+	if r := Request(client, srv.URL+"/here", "test"); r.Error != nil {
+		t.Fatal(r.Error)
+	}
+	if sameHost.Get("apikey") != "secret" || !sameHost.Has(CacheBustParam) {
+		t.Errorf("same host got query %v, want apikey and %s", sameHost, CacheBustParam)
+	}
+}
@@ -0,0 +1,89 @@
+package httpclient
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// httpOnlyPrefix marks HttpOnly cookies in curl/browser exports; the line is
+// a cookie, not a comment
+const httpOnlyPrefix = "#HttpOnly_"
+
+// LoadCookieJar reads a Netscape/Mozilla cookies.txt file (as exported by
+// browsers and curl -c) into a jar that scopes cookies by domain and path.
+// Expired cookies are skipped; an expiry of 0 is a session cookie. It also
+// returns the number of cookies loaded.
+func LoadCookieJar(path string) (http.CookieJar, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open cookie jar: %w", err)
+	}
+	defer file.Close()
+
+	jar, _ := cookiejar.New(nil)
+	now := time.Now()
+	loaded := 0
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		httpOnly := strings.HasPrefix(line, httpOnlyPrefix)
+		line = strings.TrimPrefix(line, httpOnlyPrefix)
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// domain, include subdomains, path, secure, expiry, name, value
+		fields := strings.Split(line, "\t")
+		if len(fields) == 6 {
+			fields = append(fields, "") // Empty values are sometimes cut off
+		}
+		if len(fields) != 7 {
+			return nil, 0, fmt.Errorf("cookie jar line %d: expected 7 tab-separated fields", lineNum)
+		}
+
+		expiry, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("cookie jar line %d: invalid expiry %q", lineNum, fields[4])
+		}
+		cookie := &http.Cookie{
+			Name:     fields[5],
+			Value:    fields[6],
+			Path:     fields[2],
+			Secure:   strings.EqualFold(fields[3], "TRUE"),
+			HttpOnly: httpOnly,
+		}
+		if expiry > 0 {
+			cookie.Expires = time.Unix(expiry, 0)
+			if cookie.Expires.Before(now) {
+				continue
+			}
+		}
+
+		host := strings.TrimPrefix(fields[0], ".")
+		if strings.EqualFold(fields[1], "TRUE") {
+			cookie.Domain = host // Domain cookie; host-only otherwise
+		}
+		scheme := "http"
+		if cookie.Secure {
+			scheme = "https"
+		}
+		jar.SetCookies(&url.URL{Scheme: scheme, Host: host, Path: cookie.Path}, []*http.Cookie{cookie})
+		loaded++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error reading cookie jar: %w", err)
+	}
+	return jar, loaded, nil
+}
@@ -0,0 +1,50 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Gate is called before every request goes on the wire, redirect hops,
+// retries and NTLM handshake messages included. It may block (rate limits,
+// connection budgets) and fail to cancel the request; release runs once the
+// response body is closed.
+type Gate func(req *http.Request) (release func(), err error)
+
+// gateTransport passes every request through a Gate
+type gateTransport struct {
+	base http.RoundTripper
+	gate Gate
+}
+
+func (t *gateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.gate(req)
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &gatedBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// gatedBody releases the gate of its request when closed
+type gatedBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *gatedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
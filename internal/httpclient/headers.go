@@ -0,0 +1,61 @@
+package httpclient
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ParseHeader splits a "Name: Value" header line
+func ParseHeader(line string) (name, value string, err error) {
+	name, value, ok := strings.Cut(line, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", fmt.Errorf("malformed header %q (expected \"Name: Value\")", line)
+	}
+	return name, strings.TrimSpace(value), nil
+}
+
+// BasicAuth returns the Authorization value for user:pass credentials
+func BasicAuth(creds string) (string, error) {
+	user, _, ok := strings.Cut(creds, ":")
+	if !ok || user == "" {
+		return "", fmt.Errorf("invalid basic auth credentials (expected user:pass)")
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(creds)), nil
+}
+
+// headerTransport sets user-supplied headers on every outgoing request,
+// replacing the defaults of the request helpers (User-Agent, Accept, ...).
+// They often carry credentials (-H, -auth, -bearer), so followed redirects
+// to another host go without them.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if redirectedAway(req) {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		if strings.EqualFold(name, "Host") {
+			req.Host = values[0]
+			continue
+		}
+		req.Header[name] = values
+	}
+	return t.base.RoundTrip(req)
+}
+
+// redirectedAway reports whether req is a followed redirect to another host
+// than the request that started the chain
+func redirectedAway(req *http.Request) bool {
+	first := req
+	for first.Response != nil && first.Response.Request != nil {
+		first = first.Response.Request
+	}
+	return !strings.EqualFold(first.URL.Host, req.URL.Host)
+}
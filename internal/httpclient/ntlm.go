@@ -0,0 +1,347 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// NTLMCredentials authenticate against NTLM/Negotiate protected targets
+type NTLMCredentials struct {
+	Domain   string
+	User     string
+	Password string
+}
+
+// ParseNTLMCredentials parses "user:pass", "DOMAIN\user:pass" or "user@domain:pass"
+func ParseNTLMCredentials(value string) (*NTLMCredentials, error) {
+	userPart, password, ok := strings.Cut(value, ":")
+	if !ok || userPart == "" {
+		return nil, fmt.Errorf("invalid NTLM credentials: expected [DOMAIN\\]user:pass")
+	}
+
+	creds := &NTLMCredentials{User: userPart, Password: password}
+	if domain, user, ok := strings.Cut(userPart, `\`); ok {
+		creds.Domain, creds.User = domain, user
+	} else if user, domain, ok := strings.Cut(userPart, "@"); ok {
+		creds.Domain, creds.User = domain, user
+	}
+	return creds, nil
+}
+
+// NTLM negotiate flags
+const (
+	ntlmNegotiateUnicode     = 0x00000001
+	ntlmNegotiateOEM         = 0x00000002
+	ntlmRequestTarget        = 0x00000004
+	ntlmNegotiateNTLM        = 0x00000200
+	ntlmNegotiateAlwaysSign  = 0x00008000
+	ntlmNegotiateExtendedSec = 0x00080000
+	ntlmNegotiateTargetInfo  = 0x00800000
+	ntlmNegotiate128         = 0x20000000
+	ntlmNegotiate56          = 0x80000000
+)
+
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+// ntlmTransport performs the NTLM handshake when a target answers 401 with
+// WWW-Authenticate: NTLM (or Negotiate). NTLM authenticates the connection,
+// so the three messages rely on keep-alive reusing it; a handshake that lost
+// its connection to another request is retried once. Redirects followed to
+// another host never get the credentials.
+type ntlmTransport struct {
+	base  http.RoundTripper
+	creds *NTLMCredentials
+}
+
+func (t *ntlmTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || redirectedAway(req) {
+		return resp, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		scheme := ntlmScheme(resp)
+		if resp.StatusCode != http.StatusUnauthorized || scheme == "" {
+			return resp, nil
+		}
+		drainBody(resp)
+
+		// Type 1: negotiate
+		negotiate, err := cloneRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		negotiate.Header.Set("Authorization", scheme+" "+base64.StdEncoding.EncodeToString(ntlmNegotiateMessage()))
+		resp, err = t.base.RoundTrip(negotiate)
+		if err != nil {
+			return nil, err
+		}
+
+		// Type 2: server challenge
+		challenge, ok := ntlmChallengeToken(resp, scheme)
+		if !ok {
+			return resp, nil
+		}
+		authenticate, err := ntlmAuthenticateMessage(challenge, t.creds)
+		if err != nil {
+			return resp, nil
+		}
+		drainBody(resp)
+
+		// Type 3: authenticate
+		authReq, err := cloneRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		authReq.Header.Set("Authorization", scheme+" "+base64.StdEncoding.EncodeToString(authenticate))
+		resp, err = t.base.RoundTrip(authReq)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// ntlmScheme returns the NTLM-capable scheme offered by a response, if any
+func ntlmScheme(resp *http.Response) string {
+	for _, value := range resp.Header.Values("WWW-Authenticate") {
+		scheme, _, _ := strings.Cut(strings.TrimSpace(value), " ")
+		if strings.EqualFold(scheme, "NTLM") {
+			return "NTLM"
+		}
+		if strings.EqualFold(scheme, "Negotiate") {
+			return "Negotiate"
+		}
+	}
+	return ""
+}
+
+// ntlmChallengeToken extracts the decoded challenge message of a 401 response
+func ntlmChallengeToken(resp *http.Response, scheme string) ([]byte, bool) {
+	if resp.StatusCode != http.StatusUnauthorized {
+		return nil, false
+	}
+	for _, value := range resp.Header.Values("WWW-Authenticate") {
+		name, token, ok := strings.Cut(strings.TrimSpace(value), " ")
+		if !ok || !strings.EqualFold(name, scheme) {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(token))
+		if err == nil && bytes.HasPrefix(data, ntlmSignature) {
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+// cloneRequest copies a request for a handshake leg, replaying its body
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errors.New("ntlm: request body cannot be replayed")
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// drainBody discards and closes a body so its connection can be reused
+func drainBody(resp *http.Response) {
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+}
+
+// ntlmNegotiateMessage builds the type 1 message (no domain or workstation)
+func ntlmNegotiateMessage() []byte {
+	msg := make([]byte, 32)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], 1)
+	binary.LittleEndian.PutUint32(msg[12:], ntlmNegotiateUnicode|ntlmNegotiateOEM|ntlmRequestTarget|
+		ntlmNegotiateNTLM|ntlmNegotiateAlwaysSign|ntlmNegotiateExtendedSec|ntlmNegotiate128|ntlmNegotiate56)
+	return msg
+}
+
+// ntlmAuthenticateMessage builds the type 3 message with an NTLMv2 response
+// to the type 2 challenge
+func ntlmAuthenticateMessage(challenge []byte, creds *NTLMCredentials) ([]byte, error) {
+	if len(challenge) < 48 || binary.LittleEndian.Uint32(challenge[8:]) != 2 {
+		return nil, errors.New("ntlm: malformed challenge message")
+	}
+	flags := binary.LittleEndian.Uint32(challenge[20:])
+	serverChallenge := challenge[24:32]
+	targetInfo, err := securityBuffer(challenge, 40)
+	if err != nil {
+		return nil, err
+	}
+
+	clientChallenge := make([]byte, 8)
+	if _, err := rand.Read(clientChallenge); err != nil {
+		return nil, err
+	}
+
+	// Prefer the server's timestamp; it also means LMv2 must be empty
+	timestamp, hasTimestamp := avTimestamp(targetInfo)
+	if !hasTimestamp {
+		timestamp = make([]byte, 8)
+		ft := uint64(time.Now().UnixNano()/100) + 116444736000000000 // 100ns since 1601
+		binary.LittleEndian.PutUint64(timestamp, ft)
+	}
+
+	// NTOWFv2 = HMAC-MD5(MD4(UTF-16LE(password)), UTF-16LE(UPPER(user) + domain))
+	ntHash := md4Sum(utf16le(creds.Password))
+	ntowf := hmacMD5(ntHash[:], utf16le(strings.ToUpper(creds.User)+creds.Domain))
+
+	var temp bytes.Buffer
+	temp.Write([]byte{1, 1, 0, 0, 0, 0, 0, 0})
+	temp.Write(timestamp)
+	temp.Write(clientChallenge)
+	temp.Write([]byte{0, 0, 0, 0})
+	temp.Write(targetInfo)
+	temp.Write([]byte{0, 0, 0, 0})
+
+	ntProof := hmacMD5(ntowf, append(append([]byte{}, serverChallenge...), temp.Bytes()...))
+	ntResponse := append(ntProof, temp.Bytes()...)
+
+	lmResponse := make([]byte, 24)
+	if !hasTimestamp {
+		lmProof := hmacMD5(ntowf, append(append([]byte{}, serverChallenge...), clientChallenge...))
+		lmResponse = append(lmProof, clientChallenge...)
+	}
+
+	domain := utf16le(creds.Domain)
+	user := utf16le(creds.User)
+	workstation := utf16le("")
+
+	// Fixed 64-byte header followed by the payload
+	msg := make([]byte, 64)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], 3)
+	offset := uint32(64)
+	for i, field := range [][]byte{lmResponse, ntResponse, domain, user, workstation, nil} {
+		pos := 12 + i*8
+		binary.LittleEndian.PutUint16(msg[pos:], uint16(len(field)))
+		binary.LittleEndian.PutUint16(msg[pos+2:], uint16(len(field)))
+		binary.LittleEndian.PutUint32(msg[pos+4:], offset)
+		offset += uint32(len(field))
+	}
+	binary.LittleEndian.PutUint32(msg[60:], flags&^ntlmNegotiateOEM|ntlmNegotiateUnicode)
+
+	for _, field := range [][]byte{lmResponse, ntResponse, domain, user, workstation} {
+		msg = append(msg, field...)
+	}
+	return msg, nil
+}
+
+// securityBuffer reads the (length, offset) field at pos of an NTLM message
+func securityBuffer(msg []byte, pos int) ([]byte, error) {
+	length := int(binary.LittleEndian.Uint16(msg[pos:]))
+	offset := int(binary.LittleEndian.Uint32(msg[pos+4:]))
+	if offset+length > len(msg) {
+		return nil, errors.New("ntlm: security buffer out of range")
+	}
+	return msg[offset : offset+length], nil
+}
+
+// avTimestamp returns the MsvAvTimestamp pair of a target info block
+func avTimestamp(info []byte) ([]byte, bool) {
+	for len(info) >= 4 {
+		id := binary.LittleEndian.Uint16(info)
+		length := int(binary.LittleEndian.Uint16(info[2:]))
+		if id == 0 || len(info) < 4+length {
+			break
+		}
+		if id == 7 && length == 8 {
+			return info[4:12], true
+		}
+		info = info[4+length:]
+	}
+	return nil, false
+}
+
+func hmacMD5(key, data []byte) []byte {
+	mac := hmac.New(md5.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func utf16le(s string) []byte {
+	codes := utf16.Encode([]rune(s))
+	b := make([]byte, len(codes)*2)
+	for i, c := range codes {
+		binary.LittleEndian.PutUint16(b[i*2:], c)
+	}
+	return b
+}
+
+// md4Sum implements MD4 (RFC 1320), needed for the NT hash and not in the
+// standard library
+func md4Sum(data []byte) [16]byte {
+	a, b, c, d := uint32(0x67452301), uint32(0xefcdab89), uint32(0x98badcfe), uint32(0x10325476)
+
+	// Padding: 0x80, zeros to 56 mod 64, then the bit length
+	msg := append(append([]byte{}, data...), 0x80)
+	for len(msg)%64 != 56 {
+		msg = append(msg, 0)
+	}
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(data))*8)
+	msg = append(msg, length[:]...)
+
+	rotl := func(x uint32, s uint) uint32 { return x<<s | x>>(32-s) }
+
+	var x [16]uint32
+	for chunk := 0; chunk < len(msg); chunk += 64 {
+		for i := range x {
+			x[i] = binary.LittleEndian.Uint32(msg[chunk+i*4:])
+		}
+		aa, bb, cc, dd := a, b, c, d
+
+		// Round 1
+		for _, i := range []int{0, 4, 8, 12} {
+			a = rotl(a+(b&c|^b&d)+x[i], 3)
+			d = rotl(d+(a&b|^a&c)+x[i+1], 7)
+			c = rotl(c+(d&a|^d&b)+x[i+2], 11)
+			b = rotl(b+(c&d|^c&a)+x[i+3], 19)
+		}
+		// Round 2
+		for _, i := range []int{0, 1, 2, 3} {
+			a = rotl(a+(b&c|b&d|c&d)+x[i]+0x5a827999, 3)
+			d = rotl(d+(a&b|a&c|b&c)+x[i+4]+0x5a827999, 5)
+			c = rotl(c+(d&a|d&b|a&b)+x[i+8]+0x5a827999, 9)
+			b = rotl(b+(c&d|c&a|d&a)+x[i+12]+0x5a827999, 13)
+		}
+		// Round 3
+		for _, i := range []int{0, 2, 1, 3} {
+			a = rotl(a+(b^c^d)+x[i]+0x6ed9eba1, 3)
+			d = rotl(d+(a^b^c)+x[i+8]+0x6ed9eba1, 9)
+			c = rotl(c+(d^a^b)+x[i+4]+0x6ed9eba1, 11)
+			b = rotl(b+(c^d^a)+x[i+12]+0x6ed9eba1, 15)
+		}
+
+		a, b, c, d = a+aa, b+bb, c+cc, d+dd
+	}
+
+	var sum [16]byte
+	binary.LittleEndian.PutUint32(sum[0:], a)
+	binary.LittleEndian.PutUint32(sum[4:], b)
+	binary.LittleEndian.PutUint32(sum[8:], c)
+	binary.LittleEndian.PutUint32(sum[12:], d)
+	return sum
+}
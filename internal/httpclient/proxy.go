@@ -0,0 +1,29 @@
+package httpclient
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParseProxy validates a -proxy URL: http://, https://, socks5:// or
+// socks5h:// (remote DNS), optionally with user:pass@ credentials. A bare
+// host:port is an HTTP proxy.
+func ParseProxy(raw string) (*url.URL, error) {
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", raw, err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme in %q (use http, https, socks5 or socks5h)", raw)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", raw)
+	}
+	return u, nil
+}
@@ -0,0 +1,146 @@
+package httpclient
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// FuzzMarker is the placeholder replaced by each wordlist entry
+const FuzzMarker = "FUZZ"
+
+// RawRequest is a raw HTTP request (e.g. exported from Burp) used as a template
+type RawRequest struct {
+	Method  string
+	Scheme  string
+	Host    string
+	Path    string
+	Headers [][2]string // Preserve original order and casing
+	Body    string
+}
+
+// LoadRawRequest reads and parses a raw HTTP request file
+func LoadRawRequest(path string, scheme string) (*RawRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request file: %w", err)
+	}
+	return ParseRawRequest(data, scheme)
+}
+
+// ParseRawRequest parses a raw HTTP/1.x request. The scheme is not part of the
+// raw format so it must be supplied by the caller (http or https).
+func ParseRawRequest(data []byte, scheme string) (*RawRequest, error) {
+	scheme = strings.ToLower(strings.TrimSuffix(scheme, "://"))
+	if scheme != "http" && scheme != "https" {
+		return nil, fmt.Errorf("invalid request scheme: %s (use http or https)", scheme)
+	}
+
+	reader := bufio.NewReader(bytes.NewReader(data))
+
+	// Request line: METHOD PATH PROTO
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return nil, fmt.Errorf("empty request file")
+	}
+	parts := strings.Fields(line)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("malformed request line: %q", strings.TrimSpace(line))
+	}
+
+	raw := &RawRequest{
+		Method: parts[0],
+		Scheme: scheme,
+		Path:   parts[1],
+	}
+
+	// Headers until the first blank line
+	for {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("malformed header: %q", line)
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if strings.EqualFold(name, "Host") {
+			raw.Host = value
+		} else {
+			raw.Headers = append(raw.Headers, [2]string{name, value})
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if raw.Host == "" {
+		return nil, fmt.Errorf("request file has no Host header")
+	}
+
+	// Remaining data is the body
+	body, _ := io.ReadAll(reader)
+	raw.Body = string(body)
+
+	// Absolute-form request targets carry their own host
+	if u, err := url.Parse(raw.Path); err == nil && u.IsAbs() {
+		raw.Path = u.RequestURI()
+	}
+
+	return raw, nil
+}
+
+// BaseURL returns scheme://host of the templated request
+func (r *RawRequest) BaseURL() string {
+	return fmt.Sprintf("%s://%s", r.Scheme, r.Host)
+}
+
+// URL returns the request URL with the marker replaced by word
+func (r *RawRequest) URL(word string) string {
+	return strings.ReplaceAll(r.BaseURL()+r.Path, FuzzMarker, word)
+}
+
+// HasMarker reports whether the template contains the FUZZ marker anywhere
+func (r *RawRequest) HasMarker() bool {
+	if strings.Contains(r.Path, FuzzMarker) || strings.Contains(r.Host, FuzzMarker) || strings.Contains(r.Body, FuzzMarker) {
+		return true
+	}
+	for _, h := range r.Headers {
+		if strings.Contains(h[0], FuzzMarker) || strings.Contains(h[1], FuzzMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Build creates an http.Request with every marker replaced by word
+func (r *RawRequest) Build(word string) (*http.Request, error) {
+	var body io.Reader
+	if r.Body != "" {
+		body = strings.NewReader(strings.ReplaceAll(r.Body, FuzzMarker, word))
+	}
+
+	req, err := http.NewRequest(r.Method, r.URL(word), body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range r.Headers {
+		name := strings.ReplaceAll(h[0], FuzzMarker, word)
+		// Content-Length is recomputed from the substituted body
+		if strings.EqualFold(name, "Content-Length") {
+			continue
+		}
+		req.Header.Add(name, strings.ReplaceAll(h[1], FuzzMarker, word))
+	}
+
+	return req, nil
+}
@@ -0,0 +1,66 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// retryBackoff is the delay before the first retry, doubled for each next one
+const retryBackoff = 250 * time.Millisecond
+
+// IsTransient reports whether a failed result is worth retrying: timeouts,
+// reset or dropped connections, and gateway errors (502, 503, 504)
+func IsTransient(r *Result) bool {
+	if r.Error == nil {
+		return r.StatusCode == http.StatusBadGateway ||
+			r.StatusCode == http.StatusServiceUnavailable ||
+			r.StatusCode == http.StatusGatewayTimeout
+	}
+
+	var netErr net.Error
+	if errors.As(r.Error, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(r.Error, syscall.ECONNRESET) ||
+		errors.Is(r.Error, syscall.EPIPE) ||
+		errors.Is(r.Error, io.EOF) ||
+		errors.Is(r.Error, io.ErrUnexpectedEOF)
+}
+
+// Retry calls do until its result is not transient or retries extra attempts
+// were made, with exponential backoff between attempts. The last result is
+// returned as is; ctx ending interrupts the backoff.
+func Retry(ctx context.Context, retries int, do func() *Result) *Result {
+	r := do()
+	for attempt := 0; attempt < retries && IsTransient(r); attempt++ {
+		timer := time.NewTimer(retryBackoff << attempt)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return r
+		case <-timer.C:
+		}
+		r = do()
+	}
+	return r
+}
+
+// Rewound returns req ready to be sent again: requests with a body get a
+// fresh copy of it, since sending consumes the body
+func Rewound(req *http.Request) *http.Request {
+	if req.GetBody == nil || req.Body == nil || req.Body == http.NoBody {
+		return req
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return req
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone
+}
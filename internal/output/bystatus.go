@@ -0,0 +1,89 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// StatusWriter routes findings to one file per status code (dir/200.txt,
+// dir/403.txt, ...), each written in the same format as -o
+type StatusWriter struct {
+	mu      sync.Mutex
+	dir     string
+	format  string
+	writers map[int]*Writer
+	seen    map[string]bool // Trailing-slash-normalized URLs already written
+	full    bool            // Full URLs in path-only formats, see SetFullURLs
+}
+
+// NewStatusWriter creates dir if needed and returns a sink writing into it
+func NewStatusWriter(dir string, format string) (*StatusWriter, error) {
+	if _, ok := lineFormats[format]; !ok && format != "" && format != FormatTree {
+		return nil, fmt.Errorf("unknown output format: %s (available: %s)", format, strings.Join(Formats(), ", "))
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &StatusWriter{
+		dir:     dir,
+		format:  format,
+		writers: make(map[int]*Writer),
+		seen:    make(map[string]bool),
+	}, nil
+}
+
+// WriteFinding writes f to the file of its status code, creating it on first use
+func (s *StatusWriter) WriteFinding(f *Finding) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.TrimRight(f.URL, "/")
+	if s.seen[key] {
+		return nil
+	}
+	s.seen[key] = true
+
+	w, ok := s.writers[f.StatusCode]
+	if !ok {
+		var err error
+		w, err = NewWriter(filepath.Join(s.dir, fmt.Sprintf("%d.txt", f.StatusCode)), s.format)
+		if err != nil {
+			return err
+		}
+		if s.full {
+			w.SetFullURLs()
+		}
+		s.writers[f.StatusCode] = w
+	}
+	return w.WriteFinding(f)
+}
+
+// SetFullURLs makes the per-status files write full URLs, see Writer.SetFullURLs
+func (s *StatusWriter) SetFullURLs() {
+	s.mu.Lock()
+	s.full = true
+	s.mu.Unlock()
+}
+
+// Close finishes every per-status file
+func (s *StatusWriter) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for _, w := range s.writers {
+		errs = append(errs, w.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Files returns the number of per-status files written
+func (s *StatusWriter) Files() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.writers)
+}
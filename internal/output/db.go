@@ -0,0 +1,117 @@
+package output
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// dbDriver is the database/sql driver name of modernc.org/sqlite, registered
+// by builds with the sqlite tag (see sqlite_driver.go)
+const dbDriver = "sqlite"
+
+// ErrNoSQLite is returned by NewDBWriter in builds without the sqlite tag
+var ErrNoSQLite = errors.New("SQLite support not built in (go build -tags sqlite ./cmd/xsearch)")
+
+const dbSchema = `CREATE TABLE IF NOT EXISTS findings (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	target       TEXT    NOT NULL,
+	url          TEXT    NOT NULL,
+	status       INTEGER NOT NULL,
+	size         INTEGER NOT NULL,
+	content_type TEXT    NOT NULL,
+	hash         TEXT    NOT NULL,
+	severity     INTEGER NOT NULL,
+	timestamp    TEXT    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS findings_target_url ON findings (target, url);`
+
+const dbInsert = `INSERT INTO findings
+	(target, url, status, size, content_type, hash, severity, timestamp)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+// DBWriter appends findings to the findings table of a SQLite database.
+// Repeated scans accumulate in the same table, told apart by target and
+// timestamp; severity is the syslog severity of FindingSeverity.
+type DBWriter struct {
+	mu     sync.Mutex
+	db     *sql.DB
+	insert *sql.Stmt
+	target string
+}
+
+// NewDBWriter opens (or creates) the database at path, recording findings
+// under target
+func NewDBWriter(path string, target string) (*DBWriter, error) {
+	if !slices.Contains(sql.Drivers(), dbDriver) {
+		return nil, ErrNoSQLite
+	}
+
+	db, err := sql.Open(dbDriver, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite allows a single writer
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(dbSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create findings table: %w", err)
+	}
+	insert, err := db.Prepare(dbInsert)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to prepare insert: %w", err)
+	}
+
+	return &DBWriter{db: db, insert: insert, target: target}, nil
+}
+
+// ForTarget returns a sink recording findings under target in the same
+// database, for the scans of a batch. Closing it is a no-op: the DBWriter
+// is closed by its owner.
+func (d *DBWriter) ForTarget(target string) Sink {
+	return &dbTargetSink{d: d, target: target}
+}
+
+// WriteFinding inserts a finding row
+func (d *DBWriter) WriteFinding(f *Finding) error {
+	return d.write(d.target, f)
+}
+
+func (d *DBWriter) write(target string, f *Finding) error {
+	ts := f.Time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, err := d.insert.Exec(target, f.URL, f.StatusCode, f.Size, f.ContentType, f.Hash,
+		FindingSeverity(f), ts.UTC().Format(time.RFC3339))
+	return err
+}
+
+// Close closes the database
+func (d *DBWriter) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return errors.Join(d.insert.Close(), d.db.Close())
+}
+
+// dbTargetSink writes to a DBWriter under its own target
+type dbTargetSink struct {
+	d      *DBWriter
+	target string
+}
+
+func (s *dbTargetSink) WriteFinding(f *Finding) error {
+	return s.d.write(s.target, f)
+}
+
+func (s *dbTargetSink) Close() error {
+	return nil
+}
@@ -0,0 +1,31 @@
+package output
+
+import "time"
+
+// Finding holds the metadata of a single reported result
+type Finding struct {
+	URL         string
+	StatusCode  int
+	Size        int64
+	IsDir       bool
+	Depth       int
+	Source      string    // How the URL was discovered (wordlist, robots, ...)
+	Time        time.Time // When the finding was reported
+	Redirect    string    // Location header of redirects, or the final URL of a followed chain
+	Chain       []string  // Followed redirects (-follow), request first and final URL last
+	Loop        bool      // Redirects to itself (or loops)
+	Tags        []string  // Labels from the rules file
+	ContentType string
+	Duration    time.Duration // Response time of the probe
+	SizeDiffers bool          // HEAD Content-Length disagrees with the body size
+	HeadSize    int64         // HEAD Content-Length, set when SizeDiffers
+	Confidence  float64       // 0-1 score combining status, size, hash and content type
+	Hash        string        // MD5 of the body, when it was read
+	Body        []byte        // The body read, for sinks only (not kept by the engine)
+}
+
+// Sink consumes findings as they are reported (syslog, databases, ...)
+type Sink interface {
+	WriteFinding(f *Finding) error
+	Close() error
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Output file formats selectable with -of
+const (
+	FormatTree        = "tree"
+	FormatDirsearch   = "dirsearch"
+	FormatGobuster    = "gobuster"
+	FormatJSON        = "json"
+	FormatJSONCompact = "json-compact"
+)
+
+// lineFormatter renders one finding as a single output line
+type lineFormatter func(f *Finding) string
+
+// lineFormats are the streaming formats (the tree is built on Close instead)
+var lineFormats = map[string]lineFormatter{
+	FormatDirsearch:   formatDirsearch,
+	FormatGobuster:    formatGobuster,
+	FormatJSON:        formatJSON,
+	FormatJSONCompact: formatJSONCompact,
+}
+
+// Formats returns the names of all supported output formats
+func Formats() []string {
+	formats := []string{FormatTree}
+	for name := range lineFormats {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats[1:])
+	return formats
+}
+
+// fullURLFormats replace the path-only formats in files shared by several
+// targets (-l), where the path alone would not tell the hosts apart
+var fullURLFormats = map[string]lineFormatter{
+	FormatDirsearch: func(f *Finding) string { return dirsearchLine(f, f.URL) },
+	FormatGobuster:  func(f *Finding) string { return gobusterLine(f, f.URL) },
+}
+
+// formatDirsearch mimics dirsearch: [HH:MM:SS] STATUS - SIZE - /path
+func formatDirsearch(f *Finding) string {
+	return dirsearchLine(f, urlPath(f.URL))
+}
+
+// formatGobuster mimics gobuster dir mode: /path (Status: 200) [Size: 1234]
+func formatGobuster(f *Finding) string {
+	return gobusterLine(f, urlPath(f.URL))
+}
+
+func dirsearchLine(f *Finding, target string) string {
+	return fmt.Sprintf("[%s] %d - %5s - %s", f.Time.Format("15:04:05"), f.StatusCode, dirsearchSize(f.Size), target)
+}
+
+func gobusterLine(f *Finding, target string) string {
+	return fmt.Sprintf("%s (Status: %d) [Size: %d]", target, f.StatusCode, f.Size)
+}
+
+// dirsearchSize formats sizes the way dirsearch does (integer B/KB/MB)
+func dirsearchSize(size int64) string {
+	switch {
+	case size < 0:
+		return "0B"
+	case size < 1024:
+		return fmt.Sprintf("%dB", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%dKB", size/1024)
+	default:
+		return fmt.Sprintf("%dMB", size/(1024*1024))
+	}
+}
+
+// urlPath returns the path (and query) of a URL, as relative tools print it
+func urlPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	path := u.EscapedPath()
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return path
+}
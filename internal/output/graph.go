@@ -0,0 +1,111 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// GraphWriter saves findings as a JSON parent-child graph of paths
+type GraphWriter struct {
+	mu       sync.Mutex
+	path     string
+	urls     []string
+	findings map[string]*Finding
+}
+
+// GraphNode is a path in the discovered structure. Intermediate nodes that
+// were never reported themselves have no URL.
+type GraphNode struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	URL        string  `json:"url,omitempty"`
+	Status     int     `json:"status,omitempty"`
+	Size       int64   `json:"size,omitempty"`
+	IsDir      bool    `json:"dir,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// GraphEdge links a path to a path it contains
+type GraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// Graph is the serialized form written by GraphWriter
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// NewGraphWriter creates a graph sink writing to path on Close
+func NewGraphWriter(path string) *GraphWriter {
+	return &GraphWriter{
+		path:     path,
+		findings: make(map[string]*Finding),
+	}
+}
+
+// WriteFinding collects a finding for the graph
+func (g *GraphWriter) WriteFinding(f *Finding) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.findings[f.URL]; !ok {
+		g.urls = append(g.urls, f.URL)
+	}
+	copied := *f
+	g.findings[f.URL] = &copied
+	return nil
+}
+
+// Close builds the graph from the URL tree and writes it as JSON
+func (g *GraphWriter) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// Sorted like the tree output: "dir/" follows "dir" and describes the node
+	sort.Strings(g.urls)
+
+	graph := Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	g.walk(buildTree(g.urls), "", &graph)
+
+	data, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(g.path, append(data, '\n'), 0644)
+}
+
+// walk adds the children of node (whose id is parentID) to the graph
+func (g *GraphWriter) walk(node *TreeNode, parentID string, graph *Graph) {
+	var keys []string
+	for k := range node.children {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		child := node.children[key]
+		id := key
+		if parentID != "" {
+			id = parentID + "/" + key
+		}
+
+		n := GraphNode{ID: id, Name: key, URL: child.fullURL}
+		if f, ok := g.findings[child.fullURL]; ok {
+			n.Status = f.StatusCode
+			n.Size = f.Size
+			n.IsDir = f.IsDir || strings.HasSuffix(f.URL, "/")
+			n.Confidence = f.Confidence
+		}
+		graph.Nodes = append(graph.Nodes, n)
+		if parentID != "" {
+			graph.Edges = append(graph.Edges, GraphEdge{Source: parentID, Target: id})
+		}
+
+		g.walk(child, id, graph)
+	}
+}
@@ -0,0 +1,191 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// jsonFinding is the full JSON schema of a finding (-of json, -merge input):
+//
+//	{"url":"https://t/admin/","status":200,"size":1234,"is_dir":true,"depth":0,
+//	 "content_type":"text/html","source":"wordlist","confidence":0.95,
+//	 "duration_ms":12,"time":"2024-01-02T15:04:05Z"}
+//
+// content_type, redirect, redirect_chain, loop, source, tags, confidence and
+// duration_ms are omitted when empty.
+type jsonFinding struct {
+	URL         string    `json:"url"`
+	Status      int       `json:"status"`
+	Size        int64     `json:"size"`
+	IsDir       bool      `json:"is_dir"`
+	Depth       int       `json:"depth"`
+	ContentType string    `json:"content_type,omitempty"`
+	Redirect    string    `json:"redirect,omitempty"`
+	Chain       []string  `json:"redirect_chain,omitempty"`
+	Loop        bool      `json:"loop,omitempty"`
+	Source      string    `json:"source,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Confidence  float64   `json:"confidence,omitempty"`
+	DurationMS  int64     `json:"duration_ms,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// compactFinding is the minimal schema of -of json-compact, for huge scans:
+//
+//	{"u":"https://t/admin/","s":200,"z":1234}
+type compactFinding struct {
+	URL    string `json:"u"`
+	Status int    `json:"s"`
+	Size   int64  `json:"z"`
+}
+
+// jsonEntry decodes either schema
+type jsonEntry struct {
+	jsonFinding
+	compactFinding
+}
+
+func toJSONFinding(f *Finding) jsonFinding {
+	return jsonFinding{
+		URL:         f.URL,
+		Status:      f.StatusCode,
+		Size:        f.Size,
+		IsDir:       f.IsDir,
+		Depth:       f.Depth,
+		ContentType: f.ContentType,
+		Redirect:    f.Redirect,
+		Chain:       f.Chain,
+		Loop:        f.Loop,
+		Source:      f.Source,
+		Tags:        f.Tags,
+		Confidence:  f.Confidence,
+		DurationMS:  f.Duration.Milliseconds(),
+		Time:        f.Time,
+	}
+}
+
+func (j jsonFinding) finding() Finding {
+	return Finding{
+		URL:         j.URL,
+		StatusCode:  j.Status,
+		Size:        j.Size,
+		IsDir:       j.IsDir,
+		Depth:       j.Depth,
+		ContentType: j.ContentType,
+		Redirect:    j.Redirect,
+		Chain:       j.Chain,
+		Loop:        j.Loop,
+		Source:      j.Source,
+		Tags:        j.Tags,
+		Confidence:  j.Confidence,
+		Duration:    time.Duration(j.DurationMS) * time.Millisecond,
+		Time:        j.Time,
+	}
+}
+
+func (j jsonEntry) finding() Finding {
+	if j.jsonFinding.URL == "" {
+		return Finding{URL: j.compactFinding.URL, StatusCode: j.compactFinding.Status, Size: j.compactFinding.Size}
+	}
+	return j.jsonFinding.finding()
+}
+
+// formatJSON renders a finding as one JSON object per line (NDJSON)
+func formatJSON(f *Finding) string {
+	data, _ := json.Marshal(toJSONFinding(f))
+	return string(data)
+}
+
+// formatJSONCompact renders a finding as one compact JSON object per line
+func formatJSONCompact(f *Finding) string {
+	data, _ := json.Marshal(compactFinding{URL: f.URL, Status: f.StatusCode, Size: f.Size})
+	return string(data)
+}
+
+// ReadFindings loads findings from a JSON file, either NDJSON (-of json or
+// json-compact) or a single JSON array of the same objects
+func ReadFindings(path string) ([]Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read findings: %w", err)
+	}
+
+	var entries []jsonEntry
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+			var entry jsonEntry
+			if err := json.Unmarshal(text, &entry); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+			entries = append(entries, entry)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	findings := make([]Finding, 0, len(entries))
+	for _, entry := range entries {
+		if f := entry.finding(); f.URL != "" {
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
+
+// MergeFindings deduplicates findings by normalized URL, keeping the most
+// recent one, and returns them sorted by URL
+func MergeFindings(sets ...[]Finding) []Finding {
+	latest := make(map[string]Finding)
+	for _, set := range sets {
+		for _, f := range set {
+			key := NormalizeURL(f.URL)
+			if prev, ok := latest[key]; !ok || f.Time.After(prev.Time) {
+				latest[key] = f
+			}
+		}
+	}
+
+	merged := make([]Finding, 0, len(latest))
+	for _, f := range latest {
+		merged = append(merged, f)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].URL < merged[j].URL })
+	return merged
+}
+
+// NormalizeURL lowercases scheme and host, drops default ports and the
+// trailing slash, so equivalent URLs compare equal
+func NormalizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return strings.TrimRight(rawURL, "/")
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	if (u.Scheme == "http" && strings.HasSuffix(host, ":80")) || (u.Scheme == "https" && strings.HasSuffix(host, ":443")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+	u.Host = host
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	u.Fragment = ""
+	return u.String()
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"errors"
+)
+
+// MultiWriter hands every finding to several writers at once (-oA): a
+// plaintext, a JSON and a tree file sharing one basename
+type MultiWriter struct {
+	writers []*Writer
+}
+
+// multiFormats are the files written by NewMultiWriter, as extension and format
+var multiFormats = []struct {
+	ext    string
+	format string
+}{
+	{".txt", FormatGobuster},
+	{".json", FormatJSON},
+	{".tree", FormatTree},
+}
+
+// NewMultiWriter creates basename.txt, basename.json and basename.tree
+func NewMultiWriter(basename string) (*MultiWriter, error) {
+	m := &MultiWriter{}
+	for _, mf := range multiFormats {
+		w, err := NewWriter(basename+mf.ext, mf.format)
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+		m.writers = append(m.writers, w)
+	}
+	return m, nil
+}
+
+// WriteFinding writes f to every file
+func (m *MultiWriter) WriteFinding(f *Finding) error {
+	var errs []error
+	for _, w := range m.writers {
+		errs = append(errs, w.WriteFinding(f))
+	}
+	return errors.Join(errs...)
+}
+
+// Close flushes and closes every file
+func (m *MultiWriter) Close() error {
+	var errs []error
+	for _, w := range m.writers {
+		errs = append(errs, w.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// SetFullURLs makes the path-only files write full URLs, see Writer.SetFullURLs
+func (m *MultiWriter) SetFullURLs() {
+	for _, w := range m.writers {
+		w.SetFullURLs()
+	}
+}
+
+// Writers returns the underlying writers, for -checkpoint
+func (m *MultiWriter) Writers() []*Writer {
+	return m.writers
+}
+
+// Paths returns the files written
+func (m *MultiWriter) Paths() []string {
+	paths := make([]string, len(m.writers))
+	for i, w := range m.writers {
+		paths[i] = w.GetPath()
+	}
+	return paths
+}
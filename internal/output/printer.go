@@ -13,6 +13,8 @@ type Printer struct {
 	mu           sync.Mutex
 	statusFilter map[int]bool
 	showAll      bool
+	showSource   bool
+	template     lineFormatter // Custom line format (nil = decorated tree)
 }
 
 // NewPrinter creates a new output printer
@@ -32,25 +34,46 @@ func NewPrinter(statusCodes []int) *Printer {
 	return p
 }
 
-// PrintResult prints a scan result with hierarchical tree structure
-func (p *Printer) PrintResult(url string, statusCode int, size int64, isDir bool, depth int) bool {
-	if !p.showAll && !p.statusFilter[statusCode] {
-		return false
+// SetShowSource toggles the discovery source tag on terminal lines
+func (p *Printer) SetShowSource(show bool) {
+	p.showSource = show
+}
+
+// SetTemplate replaces the decorated line with a custom template
+// (see ParseTemplate). An empty template restores the default.
+func (p *Printer) SetTemplate(tmpl string) error {
+	if tmpl == "" {
+		p.template = nil
+		return nil
+	}
+	formatter, err := ParseTemplate(tmpl)
+	if err != nil {
+		return err
 	}
+	p.template = formatter
+	return nil
+}
 
-	if p.showAll && statusCode == 404 {
+// PrintResult prints a scan result with hierarchical tree structure
+func (p *Printer) PrintResult(f *Finding) bool {
+	if !p.ShouldShow(f.StatusCode) {
 		return false
 	}
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	color := p.getStatusColor(statusCode)
-	sizeStr := formatSize(size)
+	if p.template != nil {
+		fmt.Println(p.template(f))
+		return true
+	}
+
+	color := p.getStatusColor(f.StatusCode)
+	sizeStr := FormatSize(f.Size)
 
 	// Type indicator with icon
 	var typeIcon, typeColor string
-	if isDir {
+	if f.IsDir {
 		typeIcon = "📁"
 		typeColor = utils.Cyan
 	} else {
@@ -60,24 +83,52 @@ func (p *Printer) PrintResult(url string, statusCode int, size int64, isDir bool
 
 	// Build tree prefix based on depth
 	var prefix string
-	if depth == 0 {
+	if f.Depth == 0 {
 		// Root level - no prefix
 		prefix = ""
-	} else if depth == 1 {
+	} else if f.Depth == 1 {
 		// First level subdirectory
 		prefix = "├── "
 	} else {
 		// Deeper levels with visual hierarchy
-		prefix = strings.Repeat("│   ", depth-1) + "├── "
+		prefix = strings.Repeat("│   ", f.Depth-1) + "├── "
+	}
+
+	// Redirect loops are annotated rather than shown as plain redirects;
+	// followed chains show where they ended
+	var loopStr string
+	if f.Loop {
+		loopStr = fmt.Sprintf(" %s-> %s (loop)%s", utils.Yellow, f.Redirect, utils.Reset)
+	} else if len(f.Chain) > 1 {
+		loopStr = fmt.Sprintf(" %s-> %s%s", utils.Blue, f.Chain[len(f.Chain)-1], utils.Reset)
+	}
+
+	// HEAD Content-Length disagreeing with the body read by GET
+	var headStr string
+	if f.SizeDiffers {
+		headStr = fmt.Sprintf(" %s(HEAD: %s)%s", utils.Yellow, FormatSize(f.HeadSize), utils.Reset)
+	}
+
+	// Rule tags
+	var tagStr string
+	if len(f.Tags) > 0 {
+		tagStr = fmt.Sprintf(" %s{%s}%s", utils.Yellow, strings.Join(f.Tags, ","), utils.Reset)
+	}
+
+	// Optional discovery source tag
+	var sourceStr string
+	if p.showSource && f.Source != "" {
+		sourceStr = fmt.Sprintf(" %s(%s)%s", utils.Cyan, f.Source, utils.Reset)
 	}
 
-	// Format: prefix [STATUS] 📁/📄 URL [SIZE]
-	fmt.Printf("%s%s[%d]%s %s%s%s %s %s[%s]%s\n",
+	// Format: prefix [STATUS] 📁/📄 URL [-> LOCATION [(loop)]] [SIZE] (HEAD: SIZE) {tags} (source)
+	fmt.Printf("%s%s[%d]%s %s%s%s %s%s %s[%s]%s%s%s%s\n",
 		prefix,
-		color, statusCode, utils.Reset,
+		color, f.StatusCode, utils.Reset,
 		typeColor, typeIcon, utils.Reset,
-		url,
-		utils.White, sizeStr, utils.Reset)
+		f.URL, loopStr,
+		utils.White, sizeStr, utils.Reset,
+		headStr, tagStr, sourceStr)
 
 	return true
 }
@@ -98,8 +149,8 @@ func (p *Printer) getStatusColor(statusCode int) string {
 	}
 }
 
-// formatSize formats the content size
-func formatSize(size int64) string {
+// FormatSize formats a byte count the way findings print it (1.2KB)
+func FormatSize(size int64) string {
 	if size < 0 {
 		return "N/A"
 	}
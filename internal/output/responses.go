@@ -0,0 +1,122 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxResponseName bounds saved file names (most filesystems allow 255 bytes)
+const maxResponseName = 200
+
+// savedResponse is a manifest entry of ResponseSaver
+type savedResponse struct {
+	File   string `json:"file"`
+	URL    string `json:"url"`
+	Status int    `json:"status"`
+	Size   int    `json:"size"`
+}
+
+// ResponseSaver writes the body of each finding to its own file in a
+// directory, with manifest.json mapping the files back to their URLs.
+// Bodies are the ones read by the verification GET, so they are truncated
+// at its read limit.
+type ResponseSaver struct {
+	mu      sync.Mutex
+	dir     string
+	seen    map[string]bool // URLs already saved
+	names   map[string]bool // Lowercased file names in use
+	entries []savedResponse
+}
+
+// NewResponseSaver creates dir if needed and returns a sink saving into it
+func NewResponseSaver(dir string) (*ResponseSaver, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &ResponseSaver{
+		dir:   dir,
+		seen:  make(map[string]bool),
+		names: make(map[string]bool),
+	}, nil
+}
+
+// WriteFinding saves the body of f, if one was read
+func (s *ResponseSaver) WriteFinding(f *Finding) error {
+	if len(f.Body) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen[f.URL] {
+		return nil
+	}
+	s.seen[f.URL] = true
+
+	name := s.uniqueName(responseFileName(f.URL))
+	if err := os.WriteFile(filepath.Join(s.dir, name), f.Body, 0o644); err != nil {
+		return err
+	}
+	s.entries = append(s.entries, savedResponse{File: name, URL: f.URL, Status: f.StatusCode, Size: len(f.Body)})
+	return nil
+}
+
+// uniqueName suffixes name until it is not used by another saved response.
+// Names are compared case-insensitively for case-insensitive filesystems.
+func (s *ResponseSaver) uniqueName(name string) string {
+	candidate := name
+	for i := 2; s.names[strings.ToLower(candidate)]; i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	s.names[strings.ToLower(candidate)] = true
+	return candidate
+}
+
+// Close writes manifest.json
+func (s *ResponseSaver) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, "manifest.json"), append(data, '\n'), 0o644)
+}
+
+// Files returns the number of responses saved
+func (s *ResponseSaver) Files() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// responseFileName flattens a URL into a single safe file name: the scheme is
+// dropped and every character outside [A-Za-z0-9._-] becomes '_', so no path
+// separator (and thus no traversal) can remain
+func responseFileName(url string) string {
+	if i := strings.Index(url, "://"); i != -1 {
+		url = url[i+3:]
+	}
+	name := []byte(url)
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '-':
+		default:
+			name[i] = '_'
+		}
+	}
+	s := strings.TrimLeft(string(name), ".")
+	if len(s) > maxResponseName {
+		s = s[:maxResponseName]
+	}
+	if s == "" {
+		s = "response"
+	}
+	return s
+}
@@ -0,0 +1,6 @@
+//go:build sqlite
+
+package output
+
+// Registers the pure-Go (cgo-free) "sqlite" database/sql driver for -db
+import _ "modernc.org/sqlite"
@@ -0,0 +1,138 @@
+package output
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Syslog severities (RFC 5424)
+const (
+	SeverityError   = 3
+	SeverityWarning = 4
+	SeverityNotice  = 5
+	SeverityInfo    = 6
+)
+
+// syslogFacility is local0
+const syslogFacility = 16
+
+// sdID identifies xsearch structured data (32473 is the RFC 5424 example PEN)
+const sdID = "xsearch@32473"
+
+// sensitiveExts mark findings worth a higher syslog severity
+var sensitiveExts = map[string]bool{
+	"sql": true, "db": true, "sqlite": true, "mdb": true,
+	"bak": true, "backup": true, "old": true, "swp": true,
+	"env": true, "git": true, "svn": true, "htpasswd": true,
+	"zip": true, "tar": true, "gz": true, "tgz": true, "rar": true, "7z": true,
+	"key": true, "pem": true, "config": true, "conf": true,
+}
+
+// SyslogWriter sends findings as RFC 5424 messages over UDP or TCP
+type SyslogWriter struct {
+	mu       sync.Mutex
+	conn     net.Conn
+	network  string
+	hostname string
+	appName  string
+}
+
+// NewSyslogWriter connects to a syslog server, addr being "udp://host:port"
+// or "tcp://host:port" (plain host:port defaults to UDP)
+func NewSyslogWriter(addr string, appName string) (*SyslogWriter, error) {
+	network := "udp"
+	if idx := strings.Index(addr, "://"); idx != -1 {
+		network = strings.ToLower(addr[:idx])
+		addr = addr[idx+3:]
+	}
+	if network != "udp" && network != "tcp" {
+		return nil, fmt.Errorf("unsupported syslog network: %s (use udp or tcp)", network)
+	}
+
+	conn, err := net.DialTimeout(network, addr, 10*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	return &SyslogWriter{
+		conn:     conn,
+		network:  network,
+		hostname: hostname,
+		appName:  appName,
+	}, nil
+}
+
+// WriteFinding sends one finding as a structured syslog message
+func (s *SyslogWriter) WriteFinding(f *Finding) error {
+	severity := FindingSeverity(f)
+	pri := syslogFacility*8 + severity
+
+	sd := fmt.Sprintf("[%s url=\"%s\" status=\"%d\" size=\"%d\" dir=\"%t\"",
+		sdID, sdEscape(f.URL), f.StatusCode, f.Size, f.IsDir)
+	if f.Source != "" {
+		sd += fmt.Sprintf(" source=\"%s\"", sdEscape(f.Source))
+	}
+	if len(f.Tags) > 0 {
+		sd += fmt.Sprintf(" tags=\"%s\"", sdEscape(strings.Join(f.Tags, ",")))
+	}
+	sd += "]"
+
+	ts := f.Time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG
+	msg := fmt.Sprintf("<%d>1 %s %s %s %d finding %s [%d] %s",
+		pri, ts.Format(time.RFC3339Nano), s.hostname, s.appName, os.Getpid(),
+		sd, f.StatusCode, f.URL)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// TCP uses octet-counting framing (RFC 6587), UDP one message per datagram
+	if s.network == "tcp" {
+		msg = strconv.Itoa(len(msg)) + " " + msg
+	}
+	_, err := s.conn.Write([]byte(msg))
+	return err
+}
+
+// Close closes the syslog connection
+func (s *SyslogWriter) Close() error {
+	return s.conn.Close()
+}
+
+// FindingSeverity maps a finding to a syslog severity: exposed sensitive
+// files and tagged findings rank above ordinary content and access denials
+func FindingSeverity(f *Finding) int {
+	ok := f.StatusCode >= 200 && f.StatusCode < 300
+	ext := strings.TrimPrefix(path.Ext(strings.TrimRight(f.URL, "/")), ".")
+
+	switch {
+	case ok && (sensitiveExts[strings.ToLower(ext)] || strings.Contains(f.URL, "/.")):
+		return SeverityError
+	case len(f.Tags) > 0:
+		return SeverityWarning
+	case ok:
+		return SeverityNotice
+	default:
+		return SeverityInfo
+	}
+}
+
+// sdEscape escapes a structured data parameter value
+func sdEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
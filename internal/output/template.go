@@ -0,0 +1,50 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// templateTokens renders each {token} supported by -stdout-format
+var templateTokens = map[string]func(f *Finding) string{
+	"status": func(f *Finding) string { return strconv.Itoa(f.StatusCode) },
+	"size":   func(f *Finding) string { return strconv.FormatInt(f.Size, 10) },
+	"url":    func(f *Finding) string { return f.URL },
+	"path":   func(f *Finding) string { return urlPath(f.URL) },
+	"type": func(f *Finding) string {
+		if f.IsDir {
+			return "dir"
+		}
+		return "file"
+	},
+	"depth":        func(f *Finding) string { return strconv.Itoa(f.Depth) },
+	"content-type": func(f *Finding) string { return f.ContentType },
+	"duration":     func(f *Finding) string { return strconv.FormatInt(f.Duration.Milliseconds(), 10) + "ms" },
+	"redirect":     func(f *Finding) string { return f.Redirect },
+	"confidence":   func(f *Finding) string { return strconv.FormatFloat(f.Confidence, 'f', 2, 64) },
+}
+
+var templateTokenRe = regexp.MustCompile(`\{([a-z-]+)\}`)
+
+// ParseTemplate compiles a line template such as "{status} {size} {url}".
+// Unknown tokens are rejected so typos don't silently print nothing.
+func ParseTemplate(tmpl string) (lineFormatter, error) {
+	for _, m := range templateTokenRe.FindAllStringSubmatch(tmpl, -1) {
+		if _, ok := templateTokens[m[1]]; !ok {
+			return nil, fmt.Errorf("unknown template token: {%s} (available: %s)", m[1], strings.Join(TemplateTokens(), ", "))
+		}
+	}
+
+	return func(f *Finding) string {
+		return templateTokenRe.ReplaceAllStringFunc(tmpl, func(token string) string {
+			return templateTokens[token[1:len(token)-1]](f)
+		})
+	}, nil
+}
+
+// TemplateTokens returns the supported template tokens in display order
+func TemplateTokens() []string {
+	return []string{"{status}", "{size}", "{url}", "{path}", "{type}", "{depth}", "{content-type}", "{duration}", "{redirect}", "{confidence}"}
+}
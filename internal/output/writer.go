@@ -2,6 +2,7 @@ package output
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"sort"
 	"strings"
@@ -15,15 +16,26 @@ type Writer struct {
 	writer   *bufio.Writer
 	enabled  bool
 	filePath string
-	urls     []string // Collect URLs for sorted output
+	urls     []string      // Collect URLs for sorted output
+	format   lineFormatter // Streaming line format (nil = tree)
+	name     string        // Format name, for SetFullURLs
 }
 
-// NewWriter creates a new file writer
-func NewWriter(outputPath string) (*Writer, error) {
+// NewWriter creates a new file writer for the given format (tree if empty)
+func NewWriter(outputPath string, format string) (*Writer, error) {
 	w := &Writer{
 		filePath: outputPath,
 		enabled:  outputPath != "",
 		urls:     make([]string, 0, 100),
+		name:     format,
+	}
+
+	if format != "" && format != FormatTree {
+		formatter, ok := lineFormats[format]
+		if !ok {
+			return nil, fmt.Errorf("unknown output format: %s (available: %s)", format, strings.Join(Formats(), ", "))
+		}
+		w.format = formatter
 	}
 
 	if !w.enabled {
@@ -41,6 +53,14 @@ func NewWriter(outputPath string) (*Writer, error) {
 	return w, nil
 }
 
+// SetFullURLs makes the path-only formats (dirsearch, gobuster) write full
+// URLs, for files shared by the targets of -l. Must be called before writing.
+func (w *Writer) SetFullURLs() {
+	if formatter, ok := fullURLFormats[w.name]; ok {
+		w.format = formatter
+	}
+}
+
 // WriteURL collects URL for final sorted output
 func (w *Writer) WriteURL(url string) error {
 	if !w.enabled {
@@ -54,6 +74,24 @@ func (w *Writer) WriteURL(url string) error {
 	return nil
 }
 
+// WriteFinding records a finding: line formats are written immediately,
+// the tree format collects the URL for the final sorted output
+func (w *Writer) WriteFinding(f *Finding) error {
+	if !w.enabled {
+		return nil
+	}
+	if w.format == nil {
+		return w.WriteURL(f.URL)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.urls = append(w.urls, f.URL)
+	_, err := w.writer.WriteString(w.format(f) + "\n")
+	return err
+}
+
 // WriteResult writes a full result line (legacy, not used)
 func (w *Writer) WriteResult(url string, statusCode int, size int64, isDir bool) error {
 	return w.WriteURL(url)
@@ -68,6 +106,61 @@ func (w *Writer) Close() error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
+	// Line formats are already written
+	if w.format == nil {
+		if err := w.rewriteTree(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.writer.Flush(); err != nil {
+		return err
+	}
+
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// Checkpoint saves what was collected so far so an interrupted or crashed
+// scan keeps it: line formats are flushed, the tree format is rewritten
+// as a snapshot of the current URLs
+func (w *Writer) Checkpoint() error {
+	if !w.enabled {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Already closed
+	if w.file == nil {
+		return nil
+	}
+
+	if w.format == nil {
+		if err := w.rewriteTree(); err != nil {
+			return err
+		}
+	}
+	if err := w.writer.Flush(); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// rewriteTree replaces the file content with the tree of the collected URLs
+func (w *Writer) rewriteTree() error {
+	if err := w.writer.Flush(); err != nil {
+		return err
+	}
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(0, 0); err != nil {
+		return err
+	}
+
 	// Sort URLs for hierarchical display
 	sort.Strings(w.urls)
 
@@ -76,12 +169,7 @@ func (w *Writer) Close() error {
 
 	// Write tree
 	writeTree(w.writer, tree, "")
-
-	if err := w.writer.Flush(); err != nil {
-		return err
-	}
-
-	return w.file.Close()
+	return nil
 }
 
 // TreeNode represents a node in the URL tree
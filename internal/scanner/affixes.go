@@ -0,0 +1,22 @@
+package scanner
+
+// affixedWords combines word with every -prefix and -suffix, the bare word
+// first. Forms produced twice (e.g. a prefix equal to a suffix) are kept once.
+func (e *Engine) affixedWords(word string) []string {
+	prefixes := append([]string{""}, e.config.Prefixes...)
+	suffixes := append([]string{""}, e.config.Suffixes...)
+
+	seen := make(map[string]bool, len(prefixes)*len(suffixes))
+	var words []string
+	for _, prefix := range prefixes {
+		for _, suffix := range suffixes {
+			w := prefix + word + suffix
+			if seen[w] {
+				continue
+			}
+			seen[w] = true
+			words = append(words, w)
+		}
+	}
+	return words
+}
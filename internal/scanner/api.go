@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"path"
+	"strings"
+	"sync"
+)
+
+// SourceAPIExpand marks findings from the API versioning expansion
+const SourceAPIExpand = "api-expand"
+
+// apiDirNames are directory names that trigger the version expansion
+var apiDirNames = map[string]bool{
+	"api":     true,
+	"rest":    true,
+	"graphql": true,
+}
+
+// apiVersions are probed under every API directory
+var apiVersions = []string{"v1", "v2", "v3", "latest"}
+
+// expandAPIs probes common versioning paths (/api/v1, /api/latest, ...) under
+// the API directories found at depth. Discovered versions are stored as
+// directories one level deeper, so recursion and the file phase include them.
+func (e *Engine) expandAPIs(depth int) {
+	var urls []string
+	for _, dir := range e.getDirectoriesAtDepth(depth) {
+		urls = append(urls, e.apiVersionURLs(dir, depth)...)
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	e.probeDirectories(urls, depth+1, SourceAPIExpand)
+}
+
+// apiVersionURLs returns the unvisited versioning candidates under dir, found
+// at depth, if it is an API directory
+func (e *Engine) apiVersionURLs(dir string, depth int) []string {
+	if !apiDirNames[strings.ToLower(path.Base(dir))] {
+		return nil
+	}
+	var urls []string
+	for _, version := range apiVersions {
+		fullURL := dir + "/" + version
+		if !e.isExcludedURL(fullURL) && e.markVisited(fullURL, depth+1) {
+			urls = append(urls, fullURL)
+		}
+		if e.config.AddSlash && !e.isExcludedURL(fullURL+"/") && e.markVisited(fullURL+"/", depth+1) {
+			urls = append(urls, fullURL+"/")
+		}
+	}
+	return urls
+}
+
+// probeDirectories runs a fixed list of directory candidates through the
+// directory phase workers and result handling
+func (e *Engine) probeDirectories(urls []string, depth int, source string) {
+	jobs := make(chan Job, len(urls))
+	results := make(chan Result, e.config.Threads*4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < e.config.Threads && i < len(urls); i++ {
+		wg.Add(1)
+		go e.workerFast(jobs, results, &wg)
+	}
+
+	var resultWg sync.WaitGroup
+	resultWg.Add(1)
+	go e.handleDirectoryResults(results, &resultWg, depth)
+
+	for _, u := range urls {
+		jobs <- Job{URL: u, Depth: depth, Source: source}
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(results)
+	resultWg.Wait()
+}
@@ -0,0 +1,173 @@
+package scanner
+
+import (
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// Static asset directory detection (-recurse-skip-ext)
+const (
+	minAssetSamples = 3 // Findings needed before judging a directory
+)
+
+// assetStats counts findings in a directory and how many were static assets
+type assetStats struct {
+	total  int
+	static int
+	listed bool // The directory's own listing was counted
+}
+
+// assetDirNames maps conventional static asset directory names to the
+// extensions they hold
+var assetDirNames = map[string][]string{
+	"images": {"png", "jpg", "jpeg", "gif", "svg", "webp", "ico"},
+	"img":    {"png", "jpg", "jpeg", "gif", "svg", "webp", "ico"},
+	"icons":  {"png", "svg", "ico"},
+	"css":    {"css"},
+	"styles": {"css"},
+	"fonts":  {"woff", "woff2", "ttf", "eot", "otf"},
+	"js":     {"js"},
+}
+
+// contentTypeExts maps content-type subtypes to the extension users list
+var contentTypeExts = map[string]string{
+	"jpeg":               "jpg",
+	"svg+xml":            "svg",
+	"javascript":         "js",
+	"x-icon":             "ico",
+	"vnd.microsoft.icon": "ico",
+}
+
+// trackAsset records a finding in its parent directory's extension distribution
+func (e *Engine) trackAsset(url string, contentType string) {
+	if len(e.skipExts) == 0 {
+		return
+	}
+
+	path := strings.TrimRight(url, "/")
+	idx := strings.LastIndex(path, "/")
+	if idx == -1 {
+		return
+	}
+	dir, name := path[:idx], path[idx+1:]
+
+	e.assetsMux.Lock()
+	defer e.assetsMux.Unlock()
+
+	stats := e.dirAssets[dir]
+	if stats == nil {
+		stats = &assetStats{}
+		e.dirAssets[dir] = stats
+	}
+	stats.total++
+	if e.isStaticAsset(name, contentType) {
+		stats.static++
+	}
+}
+
+// isStaticAsset checks a file name extension or content type against -recurse-skip-ext
+func (e *Engine) isStaticAsset(name string, contentType string) bool {
+	if idx := strings.LastIndex(name, "."); idx != -1 {
+		if e.skipExts[strings.ToLower(name[idx+1:])] {
+			return true
+		}
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	parts := strings.SplitN(mediaType, "/", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	sub := parts[1]
+	if ext, ok := contentTypeExts[sub]; ok {
+		sub = ext
+	}
+	return e.skipExts[strings.TrimPrefix(sub, "x-")]
+}
+
+// trackListing records the files linked from a directory's own response (an
+// index page or autoindex listing), so the directory can be judged before
+// the scans that isAssetDirectory would skip
+func (e *Engine) trackListing(dir string, body []byte) {
+	if len(e.skipExts) == 0 || len(body) == 0 {
+		return
+	}
+	dir = strings.TrimRight(dir, "/")
+	base, err := url.Parse(dir + "/")
+	if err != nil {
+		return
+	}
+
+	var names []string
+	for _, ref := range parseLinks(body) {
+		u, err := base.Parse(ref)
+		if err != nil || u.Host != base.Host {
+			continue
+		}
+		// Only files directly inside the directory
+		name, ok := strings.CutPrefix(u.Path, base.Path)
+		if ok && name != "" && !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	e.assetsMux.Lock()
+	defer e.assetsMux.Unlock()
+
+	stats := e.dirAssets[dir]
+	if stats == nil {
+		stats = &assetStats{}
+		e.dirAssets[dir] = stats
+	}
+	// dir and dir/ both list the files when the server serves either
+	if stats.listed {
+		return
+	}
+	stats.listed = true
+	for _, name := range names {
+		stats.total++
+		if e.isStaticAsset(name, "") {
+			stats.static++
+		}
+	}
+}
+
+// isAssetDirectory reports whether a directory holds static assets listed in
+// -recurse-skip-ext, making deeper scanning pointless: by its conventional
+// name (images, css, fonts...), or because the files linked from its listing
+// and found in it so far are dominated by those extensions
+func (e *Engine) isAssetDirectory(dir string) bool {
+	if len(e.skipExts) == 0 {
+		return false
+	}
+
+	name := strings.TrimRight(dir, "/")
+	name = strings.ToLower(name[strings.LastIndex(name, "/")+1:])
+	for _, ext := range assetDirNames[name] {
+		if e.skipExts[ext] {
+			utils.PrintInfo("Skipping static asset directory: %s (%s files)", dir, ext)
+			return true
+		}
+	}
+
+	e.assetsMux.Lock()
+	stats := e.dirAssets[strings.TrimRight(dir, "/")]
+	e.assetsMux.Unlock()
+
+	if stats == nil || stats.total < minAssetSamples || stats.static*2 <= stats.total {
+		return false
+	}
+
+	utils.PrintInfo("Skipping static asset directory: %s (%s)", dir, fmt.Sprintf("%d/%d assets", stats.static, stats.total))
+	return true
+}
@@ -0,0 +1,163 @@
+package scanner
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+const (
+	// tuneInterval is how often -threads-auto re-evaluates the concurrency
+	tuneInterval = time.Second
+	// tuneMinSamples is the number of responses a window needs to be judged
+	tuneMinSamples = 20
+)
+
+// initSlots prepares the -threads-auto concurrency gate: Threads workers are
+// started, but only as many as the current limit may have a request in flight
+func (e *Engine) initSlots() {
+	e.slots = make(chan struct{}, e.config.Threads)
+	e.slotLimit = int64(e.config.ThreadsMin)
+	e.slotPeak = e.slotLimit
+	for i := 0; i < e.config.ThreadsMin; i++ {
+		e.slots <- struct{}{}
+	}
+}
+
+// acquireSlot takes a concurrency slot, waiting while the limit is reached.
+// Without -threads-auto it returns immediately.
+func (e *Engine) acquireSlot() (release func(), ok bool) {
+	if e.slots == nil {
+		return func() {}, true
+	}
+	select {
+	case <-e.ctx.Done():
+		return nil, false
+	case <-e.slots:
+		return e.releaseSlot, true
+	}
+}
+
+// releaseSlot returns a slot, or retires it if the limit was lowered
+func (e *Engine) releaseSlot() {
+	for {
+		debt := atomic.LoadInt64(&e.slotDebt)
+		if debt <= 0 {
+			break
+		}
+		if atomic.CompareAndSwapInt64(&e.slotDebt, debt, debt-1) {
+			return
+		}
+	}
+	select {
+	case e.slots <- struct{}{}:
+	default:
+	}
+}
+
+// observeLatency feeds a response time to the -threads-auto controller
+func (e *Engine) observeLatency(d time.Duration, err error) {
+	if e.slots == nil || err != nil {
+		return
+	}
+	atomic.AddInt64(&e.latencySum, int64(d))
+	atomic.AddUint64(&e.latencyCount, 1)
+}
+
+// startThreadTuner launches the -threads-auto controller. Closing the
+// returned channel stops it.
+func (e *Engine) startThreadTuner() chan struct{} {
+	done := make(chan struct{})
+	if e.slots == nil {
+		return done
+	}
+
+	go func() {
+		ticker := time.NewTicker(tuneInterval)
+		defer ticker.Stop()
+
+		// Lowest average latency seen: the target's unloaded response time
+		var best time.Duration
+		for {
+			select {
+			case <-done:
+				return
+			case <-e.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			count := atomic.SwapUint64(&e.latencyCount, 0)
+			sum := atomic.SwapInt64(&e.latencySum, 0)
+			if count < tuneMinSamples {
+				continue
+			}
+			avg := time.Duration(sum / int64(count))
+			if best == 0 || avg < best {
+				best = avg
+			} else {
+				// Drift slowly so a target that got slower for good is
+				// not mistaken for a saturated one forever
+				best += (avg - best) / 20
+			}
+
+			limit := atomic.LoadInt64(&e.slotLimit)
+			switch {
+			case avg > best*2:
+				// Latency doubled: back off
+				e.setSlotLimit(limit * 3 / 4)
+			case avg <= best*3/2:
+				// Latency flat: grow
+				step := limit / 4
+				if step < 1 {
+					step = 1
+				}
+				e.setSlotLimit(limit + step)
+			}
+		}
+	}()
+	return done
+}
+
+// setSlotLimit moves the concurrency limit within -threads-min/-threads-max
+func (e *Engine) setSlotLimit(limit int64) {
+	if min := int64(e.config.ThreadsMin); limit < min {
+		limit = min
+	}
+	if max := int64(e.config.Threads); limit > max {
+		limit = max
+	}
+
+	diff := limit - atomic.SwapInt64(&e.slotLimit, limit)
+	if limit > atomic.LoadInt64(&e.slotPeak) {
+		atomic.StoreInt64(&e.slotPeak, limit)
+	}
+
+	// Shrink: retire idle slots now, busy ones when they are released
+	for ; diff < 0; diff++ {
+		select {
+		case <-e.slots:
+		default:
+			atomic.AddInt64(&e.slotDebt, 1)
+		}
+	}
+
+	// Grow: cancel pending retirements first, then add slots
+	for ; diff > 0; diff-- {
+		if debt := atomic.LoadInt64(&e.slotDebt); debt > 0 &&
+			atomic.CompareAndSwapInt64(&e.slotDebt, debt, debt-1) {
+			continue
+		}
+		select {
+		case e.slots <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// printThreadStats reports where -threads-auto settled
+func (e *Engine) printThreadStats() {
+	utils.PrintInfo("Threads: auto settled at %d (peak %d, range %d-%d)",
+		atomic.LoadInt64(&e.slotLimit), atomic.LoadInt64(&e.slotPeak), e.config.ThreadsMin, e.config.Threads)
+}
@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// SourceBackup marks findings from the -backup stage
+const SourceBackup = "backup"
+
+// backupSuffixes are appended to the name of a found file
+var backupSuffixes = []string{".bak", ".old", ".orig", ".save", ".swp", "~"}
+
+// backupPrefixes are prepended to the name of a found file (Windows copies)
+var backupPrefixes = []string{"Copy%20of%20", "copy_of_"}
+
+// probeBackups probes the backup variants of every file found (config.php ->
+// config.php.bak, config.php~, .config.php.swp, ...). Editors and admins leave
+// these next to the original, often served as plain text with the source.
+func (e *Engine) probeBackups() {
+	var urls []string
+	for _, f := range e.getFindings() {
+		if f.IsDir || f.Source == SourceBackup || !isBackupCandidate(f.StatusCode) {
+			continue
+		}
+		for _, u := range buildBackupURLs(f.URL) {
+			if !e.isExcludedURL(u) && e.markVisited(u, 0) {
+				urls = append(urls, u)
+			}
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	utils.PrintInfo("Backup files: %d candidates", len(urls))
+	e.probeFiles(urls, SourceBackup)
+}
+
+// isBackupCandidate reports whether a file answering statusCode is worth
+// checking for backups: 2xx, and 401/403 whose backups may not be protected
+func isBackupCandidate(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300 || statusCode == 401 || statusCode == 403
+}
+
+// buildBackupURLs returns the common backup names of the file at foundURL
+func buildBackupURLs(foundURL string) []string {
+	idx := strings.LastIndex(foundURL, "/")
+	dir, name := foundURL[:idx+1], foundURL[idx+1:]
+	if name == "" {
+		return nil
+	}
+
+	var urls []string
+	for _, suffix := range backupSuffixes {
+		urls = append(urls, dir+name+suffix)
+	}
+	// Vim swap files are hidden
+	urls = append(urls, dir+"."+name+".swp")
+	for _, prefix := range backupPrefixes {
+		urls = append(urls, dir+prefix+name)
+	}
+	return urls
+}
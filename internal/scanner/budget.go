@@ -0,0 +1,37 @@
+package scanner
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// The -maxreq budget counts probed URLs, like the Requests stat: the HEAD and
+// verification GET of a URL, its retries, calibration and post-scan checks
+// are not counted separately.
+
+// takeBudget reserves one probe of the -maxreq budget, reporting false once
+// it is used up
+func (e *Engine) takeBudget() bool {
+	return e.config.MaxProbes <= 0 || atomic.AddUint64(&e.requested, 1) <= uint64(e.config.MaxProbes)
+}
+
+// budgetSpent reports whether every probe of the -maxreq budget was handed
+// out, so no new jobs should be queued
+func (e *Engine) budgetSpent() bool {
+	return e.config.MaxProbes > 0 && atomic.LoadUint64(&e.requested) >= uint64(e.config.MaxProbes)
+}
+
+// checkBudget stops the scan once the -maxreq budget is processed, so the
+// queued jobs are dropped and the workers drain
+func (e *Engine) checkBudget() {
+	if e.config.MaxProbes <= 0 || atomic.LoadUint64(&e.processed) < uint64(e.config.MaxProbes) {
+		return
+	}
+	if atomic.CompareAndSwapUint32(&e.budgetHit, 0, 1) {
+		fmt.Println()
+		utils.PrintWarning("Probe budget reached (%d URLs): stopping the scan", e.config.MaxProbes)
+		e.cancel()
+	}
+}
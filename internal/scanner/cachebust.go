@@ -0,0 +1,31 @@
+package scanner
+
+import (
+	"net/http"
+
+	"github.com/Fastdev75/xsearch/internal/httpclient"
+)
+
+// isCacheBustArtifact re-requests a 200 finding without the cache buster. A
+// 404 then means the server only answered 200 because a query string was
+// present, so the finding is an artifact of -cache-bust. With -X the
+// re-check uses the probe method and body.
+func (e *Engine) isCacheBustArtifact(url string) bool {
+	var req *http.Request
+	var err error
+	if e.config.Method != "" {
+		req, err = httpclient.NewDataRequest(e.config.Method, url, e.userAgent(), e.config.Data)
+	} else {
+		req, err = http.NewRequest("HEAD", url, nil)
+		if err == nil {
+			req.Header.Set("User-Agent", e.userAgent())
+			req.Header.Set("Accept", "*/*")
+		}
+	}
+	if err != nil {
+		return false
+	}
+
+	r := httpclient.Send(e.client, httpclient.WithoutCacheBust(req), false)
+	return r.Error == nil && r.StatusCode == 404
+}
@@ -0,0 +1,157 @@
+package scanner
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/httpclient"
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// calibrationShapes vary the kind of missing resource requested, since many
+// servers handle missing directories, scripts and dotfiles differently
+var calibrationShapes = []string{
+	"xsearch_%d_calibration",
+	"xsearch_%d.php",
+	"xsearch_%d/",
+	".xsearch_%d",
+	"xsearch_%d.txt",
+}
+
+// calibrationSample is one missing resource fetched with GET and HEAD
+type calibrationSample struct {
+	path string
+	get  *httpclient.Result
+	head *httpclient.Result // nil in raw request mode
+}
+
+// CalibrationReport requests samples missing resources (at least the standard
+// calibration) and prints how the target answers them: status, size and hash
+// per sample, whether GET and HEAD agree, and what kind of 404 handling this
+// is. Nothing is scanned.
+func (e *Engine) CalibrationReport(samples int) error {
+	baseURL := e.normalizeURL(e.config.TargetURL)
+	if e.config.RawRequest != nil {
+		baseURL = e.config.RawRequest.BaseURL()
+	}
+	utils.PrintInfo("Target: %s", baseURL)
+
+	// The baseline the scan would use
+	if err := e.calibrateMultiple(baseURL); err != nil {
+		return err
+	}
+	if samples < len(calibrationShapes) {
+		samples = len(calibrationShapes)
+	}
+
+	fmt.Println(strings.Repeat("─", 70))
+	collected := make([]calibrationSample, 0, samples)
+	for i := 0; i < samples; i++ {
+		token := fmt.Sprintf(calibrationShapes[i%len(calibrationShapes)], rand.Intn(1e8))
+		sample := calibrationSample{path: "/" + token}
+		if e.config.RawRequest != nil {
+			sample.get = e.calibrationRequest(baseURL, token)
+		} else {
+			url := baseURL + "/" + token
+			ua := e.userAgent()
+			sample.get = httpclient.RequestWithBody(e.client, url, ua)
+			sample.head = httpclient.HeadRequest(e.client, url, ua)
+		}
+		printCalibrationSample(&sample)
+		collected = append(collected, sample)
+	}
+	fmt.Println(strings.Repeat("─", 70))
+
+	summarizeCalibration(collected)
+	return nil
+}
+
+// printCalibrationSample prints one sample line
+func printCalibrationSample(s *calibrationSample) {
+	if s.get.Error != nil {
+		utils.PrintWarning("%-30s GET error: %v", s.path, s.get.Error)
+		return
+	}
+
+	hash := "-"
+	if len(s.get.BodyHash) >= 8 {
+		hash = s.get.BodyHash[:8]
+	}
+	line := fmt.Sprintf("%-30s GET %d size=%d hash=%s", s.path, s.get.StatusCode, s.get.Size, hash)
+	if s.get.RedirectURL != "" {
+		line += " -> " + s.get.RedirectURL
+	}
+	if s.head != nil {
+		if s.head.Error != nil {
+			line += " | HEAD error"
+		} else {
+			headSize := "?" // No Content-Length
+			if s.head.Size >= 0 {
+				headSize = fmt.Sprint(s.head.Size)
+			}
+			line += fmt.Sprintf(" | HEAD %d size=%s", s.head.StatusCode, headSize)
+		}
+	}
+	utils.PrintInfo("%s", line)
+}
+
+// summarizeCalibration classifies the target's not-found behavior and
+// suggests matching flags
+func summarizeCalibration(samples []calibrationSample) {
+	statuses := make(map[int]int)
+	hashes := make(map[string]bool)
+	sizes := make(map[int64]bool)
+	answered, disagree, headed := 0, 0, 0
+	for _, s := range samples {
+		if s.get.Error != nil {
+			continue
+		}
+		answered++
+		statuses[s.get.StatusCode]++
+		hashes[s.get.BodyHash] = true
+		sizes[s.get.Size] = true
+		if s.head != nil && s.head.Error == nil {
+			headed++
+			if s.head.StatusCode != s.get.StatusCode {
+				disagree++
+			}
+		}
+	}
+	if answered == 0 {
+		utils.PrintWarning("No calibration request got a response")
+		return
+	}
+
+	var codes []string
+	for code := range statuses {
+		codes = append(codes, fmt.Sprintf("%d (%d)", code, statuses[code]))
+	}
+	sort.Strings(codes)
+	utils.PrintInfo("Statuses: %s | distinct sizes: %d | distinct hashes: %d", strings.Join(codes, ", "), len(sizes), len(hashes))
+
+	switch {
+	case len(statuses) == 1 && statuses[404] > 0:
+		utils.PrintSuccess("Standard 404: missing resources return 404, no soft-404 tuning needed")
+	case statuses[200] > 0 && len(hashes) == 1:
+		utils.PrintWarning("Soft 404: missing resources return 200 with a static page, filtered by hash/size")
+	case statuses[200] > 0:
+		utils.PrintWarning("Dynamic soft 404: missing resources return 200 with varying bodies (path reflected?)")
+		utils.PrintWarning("Consider -size-deviation, -exclude-homepage or -mw to separate real content")
+	case len(statuses) == 1 && (statuses[301] > 0 || statuses[302] > 0 || statuses[307] > 0 || statuses[308] > 0):
+		utils.PrintWarning("Missing resources redirect: every path looks like a directory, consider -fc with the redirect code")
+	case len(statuses) == 1 && statuses[403] > 0:
+		utils.PrintWarning("Missing resources return 403: 403s are not evidence of content, consider -fc 403")
+	default:
+		utils.PrintWarning("Mixed not-found handling: the status depends on the kind of path requested")
+	}
+
+	if headed > 0 {
+		if disagree == 0 {
+			utils.PrintSuccess("HEAD and GET agree on all %d samples", headed)
+		} else {
+			utils.PrintWarning("HEAD and GET disagree on %d/%d samples: avoid -head-only and -cal-method HEAD", disagree, headed)
+		}
+	}
+}
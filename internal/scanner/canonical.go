@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// reportCanonicalAliases groups findings that redirect to the same target
+// (e.g. /Admin, /ADMIN -> /admin/) and reports each canonical URL once
+func (e *Engine) reportCanonicalAliases() {
+	aliases := make(map[string][]string)
+	findings := e.getFindings()
+
+	found := make(map[string]bool, len(findings))
+	for _, f := range findings {
+		found[strings.TrimRight(f.URL, "/")] = true
+	}
+
+	for _, f := range findings {
+		if f.Redirect == "" || f.Loop {
+			continue
+		}
+		base, err := url.Parse(f.URL)
+		if err != nil {
+			continue
+		}
+		target, err := base.Parse(f.Redirect)
+		if err != nil {
+			continue
+		}
+		canonical := target.String()
+
+		// A plain /dir -> /dir/ redirect is not an alias
+		if strings.TrimRight(canonical, "/") == strings.TrimRight(f.URL, "/") {
+			continue
+		}
+		aliases[canonical] = append(aliases[canonical], f.URL)
+	}
+
+	// Only duplicates matter: several aliases, or an alias of another finding
+	var targets []string
+	for target, urls := range aliases {
+		if len(urls) > 1 || found[strings.TrimRight(target, "/")] {
+			targets = append(targets, target)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+	sort.Strings(targets)
+
+	utils.PrintInfo("Canonical redirect targets with aliases: %d", len(targets))
+	for _, target := range targets {
+		urls := aliases[target]
+		sort.Strings(urls)
+		paths := make([]string, len(urls))
+		for i, u := range urls {
+			paths[i] = u
+			if parsed, err := url.Parse(u); err == nil {
+				paths[i] = parsed.Path
+			}
+		}
+		utils.PrintSuccess("%s (aliases: %s)", target, strings.Join(paths, ", "))
+	}
+}
@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// caseVariants returns the lowercase, uppercase and Title-case forms of word
+// that differ from it and from each other (-mutate-case)
+func caseVariants(word string) []string {
+	seen := map[string]bool{word: true}
+	var variants []string
+	for _, v := range []string{strings.ToLower(word), strings.ToUpper(word), titleCase(word)} {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		variants = append(variants, v)
+	}
+	return variants
+}
+
+// titleCase upper-cases the first letter of word and lower-cases the rest
+func titleCase(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	if r == utf8.RuneError {
+		return word
+	}
+	return string(unicode.ToUpper(r)) + strings.ToLower(word[size:])
+}
+
+// caseInflation returns the number of words and of the candidates they
+// become, counted with wordVariants so that affixes and the other variants
+// multiply in. Streamed wordlists are estimated from a lowercase word.
+func (e *Engine) caseInflation() (words, mutated int) {
+	if e.config.WordStream != nil {
+		words = e.wordCount()
+		return words, words * len(e.wordVariants("word"))
+	}
+	for _, w := range e.wordsSince(0) {
+		words++
+		mutated += len(e.wordVariants(strings.TrimSpace(w)))
+	}
+	return words, mutated
+}
@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"path"
+	"strings"
+)
+
+// Weights of the signals combined into a finding's confidence
+const (
+	weightStatus      = 0.30
+	weightSize        = 0.25
+	weightHash        = 0.20
+	weightContentType = 0.25
+)
+
+// expectedContentTypes maps file extensions to plausible Content-Type fragments
+var expectedContentTypes = map[string][]string{
+	"php":  {"html", "text"},
+	"asp":  {"html", "text"},
+	"aspx": {"html", "text"},
+	"jsp":  {"html", "text"},
+	"html": {"html"},
+	"htm":  {"html"},
+	"txt":  {"text/plain"},
+	"json": {"json"},
+	"xml":  {"xml"},
+	"js":   {"javascript", "ecmascript"},
+	"css":  {"css"},
+	"png":  {"image/"},
+	"jpg":  {"image/"},
+	"gif":  {"image/"},
+	"ico":  {"image/"},
+	"svg":  {"image/svg"},
+	"pdf":  {"pdf"},
+	"zip":  {"zip", "octet-stream"},
+	"gz":   {"gzip", "octet-stream"},
+	"tar":  {"tar", "octet-stream"},
+	"sql":  {"sql", "text/plain", "octet-stream"},
+	"bak":  {"octet-stream", "text/plain"},
+	"old":  {"octet-stream", "text/plain"},
+}
+
+// confidence scores how likely a result is a real finding (0-1), combining
+// its status code, size deviation from the calibration baseline, body hash
+// uniqueness among results and content-type plausibility
+func (e *Engine) confidence(r *Result) float64 {
+	return weightStatus*statusScore(r.StatusCode) +
+		weightSize*e.sizeScore(r.Size) +
+		weightHash*e.hashScore(r.BodyHash) +
+		weightContentType*contentTypeScore(r.URL, r.ContentType)
+}
+
+// statusScore rates status codes by how often they mean real content
+func statusScore(statusCode int) float64 {
+	switch {
+	case statusCode == 200:
+		return 1.0
+	case statusCode >= 200 && statusCode < 300:
+		return 0.9
+	case statusCode == 301 || statusCode == 302 || statusCode == 307 || statusCode == 308:
+		return 0.8
+	case statusCode == 401 || statusCode == 403:
+		return 0.6
+	case statusCode == 405:
+		return 0.5
+	default:
+		return 0.3
+	}
+}
+
+// sizeScore rewards sizes far from the baseline (the target's miss response)
+func (e *Engine) sizeScore(size int64) float64 {
+	if e.baselineSize <= 0 || size < 0 {
+		return 0.7
+	}
+	diff := float64(size - e.baselineSize)
+	if diff < 0 {
+		diff = -diff
+	}
+	// Half the baseline size away or more is a full score
+	score := diff / float64(e.baselineSize) * 2
+	if score > 1 {
+		score = 1
+	}
+	return score
+}
+
+// hashScore penalizes bodies shared by many results (templated error pages).
+// It only reads the counts, so scoring a result twice gives the same score.
+func (e *Engine) hashScore(hash string) float64 {
+	if hash == "" {
+		return 0.7
+	}
+
+	e.hashCountsMux.Lock()
+	count := e.hashCounts[hash]
+	e.hashCountsMux.Unlock()
+
+	return 1 / float64(count+1)
+}
+
+// countHash records a scored body for hashScore, once per result
+func (e *Engine) countHash(hash string) {
+	if hash == "" {
+		return
+	}
+	e.hashCountsMux.Lock()
+	e.hashCounts[hash]++
+	e.hashCountsMux.Unlock()
+}
+
+// contentTypeScore checks the Content-Type against the URL's extension
+func contentTypeScore(url string, contentType string) float64 {
+	ext := strings.TrimPrefix(path.Ext(strings.TrimRight(url, "/")), ".")
+	expected, known := expectedContentTypes[strings.ToLower(ext)]
+	if !known || contentType == "" {
+		return 0.8
+	}
+
+	contentType = strings.ToLower(contentType)
+	for _, fragment := range expected {
+		if strings.Contains(contentType, fragment) {
+			return 1.0
+		}
+	}
+	// An HTML page for a binary or data file is usually an error page
+	if strings.Contains(contentType, "html") {
+		return 0.2
+	}
+	return 0.5
+}
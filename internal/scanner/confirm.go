@@ -0,0 +1,100 @@
+package scanner
+
+import (
+	"sync"
+
+	"github.com/Fastdev75/xsearch/internal/httpclient"
+	"github.com/Fastdev75/xsearch/internal/output"
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// holdFinding keeps a file finding back until the -confirm pass
+func (e *Engine) holdFinding(f *output.Finding) {
+	held := *f
+	held.Body = nil
+	e.heldMux.Lock()
+	e.held = append(e.held, held)
+	e.heldMux.Unlock()
+}
+
+// flushHeld writes held findings unconfirmed, when the scan was interrupted
+// before the -confirm pass
+func (e *Engine) flushHeld() {
+	e.heldMux.Lock()
+	held := e.held
+	e.held = nil
+	e.heldMux.Unlock()
+	if len(held) == 0 {
+		return
+	}
+
+	utils.PrintWarning("Scan interrupted: writing %d unconfirmed findings", len(held))
+	for i := range held {
+		e.writeOutput(&held[i])
+	}
+}
+
+// confirmFindings re-requests every held finding once and drops those no
+// longer answering with a reliable status before they reach the output file.
+// Findings that were transient (server hiccups, rate limiting, flapping
+// routes) are dropped; findings an interruption left unchecked are kept.
+func (e *Engine) confirmFindings() {
+	e.heldMux.Lock()
+	held := e.held
+	e.held = nil
+	e.heldMux.Unlock()
+	if len(held) == 0 {
+		return
+	}
+
+	utils.PrintInfo("Confirming %d findings", len(held))
+
+	dropped := make([]bool, len(held))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < e.config.Threads && i < len(held); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				f := &held[idx]
+				var r *httpclient.Result
+				if e.config.Method != "" {
+					r = httpclient.DataRequest(e.client, e.config.Method, f.URL, e.userAgent(), e.config.Data, false)
+				} else if e.headOnly() {
+					r = httpclient.HeadRequest(e.client, f.URL, e.userAgent())
+				} else {
+					r = httpclient.Request(e.client, f.URL, e.userAgent())
+				}
+				switch {
+				case r.Error != nil:
+					dropped[idx] = true
+					utils.PrintWarning("Dropped unconfirmed finding: %s (%v)", f.URL, r.Error)
+				case !e.isReliableResult(r.StatusCode):
+					dropped[idx] = true
+					utils.PrintWarning("Dropped unconfirmed finding: %s (was %d, now %d)", f.URL, f.StatusCode, r.StatusCode)
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range held {
+		select {
+		case <-e.ctx.Done():
+			break feed
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	kept := 0
+	for i := range held {
+		if !dropped[i] {
+			e.writeOutput(&held[i])
+			kept++
+		}
+	}
+	utils.PrintSuccess("Kept %d/%d findings after confirmation", kept, len(held))
+}
@@ -0,0 +1,28 @@
+package scanner
+
+// ConnLimit is a request budget shared by engines scanning several targets at
+// once (-hc). Every request on the wire holds a unit until its body is
+// closed, so the requests in flight of all engines stay within its capacity
+// whatever their Threads. Idle pooled connections are not counted: each
+// engine keeps its own pool until its scan ends.
+type ConnLimit chan struct{}
+
+// NewConnLimit returns a budget of n concurrent requests
+func NewConnLimit(n int) ConnLimit {
+	return make(ConnLimit, n)
+}
+
+// acquireConn takes a unit of the shared budget, waiting while it is used
+// up. Without a ConnLimit it returns immediately.
+func (e *Engine) acquireConn() (release func(), ok bool) {
+	limit := e.config.ConnLimit
+	if limit == nil {
+		return func() {}, true
+	}
+	select {
+	case <-e.ctx.Done():
+		return nil, false
+	case limit <- struct{}{}:
+		return func() { <-limit }, true
+	}
+}
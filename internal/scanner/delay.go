@@ -0,0 +1,31 @@
+package scanner
+
+import (
+	"math/rand"
+	"time"
+)
+
+// workerDelay returns the -delay sleep of one worker, called after each of
+// its requests. A DelayMin-DelayMax range is jittered with a per-worker RNG,
+// so workers never contend on a shared source. It reports false once the
+// scan is stopped.
+func (e *Engine) workerDelay() func() bool {
+	min, max := e.config.DelayMin, e.config.DelayMax
+	if max <= 0 {
+		return func() bool { return true }
+	}
+
+	rng := rand.New(rand.NewSource(rand.Int63()))
+	return func() bool {
+		d := min
+		if max > min {
+			d += time.Duration(rng.Int63n(int64(max-min) + 1))
+		}
+		select {
+		case <-e.ctx.Done():
+			return false
+		case <-time.After(d):
+			return true
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package scanner
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Fastdev75/xsearch/internal/httpclient"
+	"github.com/Fastdev75/xsearch/internal/output"
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// headOnly reports whether verification GETs are off: -head-only was given,
+// or the -max-download budget is spent. Body-based filters keep the GETs,
+// since dropping them would silently change what is reported.
+func (e *Engine) headOnly() bool {
+	return e.config.HeadOnly || (atomic.LoadUint32(&e.downloadCapped) == 1 && !e.needsBody())
+}
+
+// countDownload adds the body read by a verification GET to the downloaded
+// total and switches the scan to HEAD-only once MaxDownload is reached
+func (e *Engine) countDownload(r *httpclient.Result) {
+	if e.config.MaxDownload <= 0 || r == nil || r.Error != nil {
+		return
+	}
+
+	total := atomic.AddUint64(&e.downloaded, uint64(len(r.Body)))
+	if total >= uint64(e.config.MaxDownload) && atomic.CompareAndSwapUint32(&e.downloadCapped, 0, 1) {
+		fmt.Println()
+		if e.needsBody() {
+			utils.PrintWarning("Download limit reached (%s read): still reading bodies, which the body filters and -save-responses need",
+				output.FormatSize(int64(total)))
+			return
+		}
+		utils.PrintWarning("Download limit reached (%s read): continuing HEAD-only, soft-404 detection by size only",
+			output.FormatSize(int64(total)))
+	}
+}
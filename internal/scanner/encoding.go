@@ -0,0 +1,36 @@
+package scanner
+
+import "net/url"
+
+// encodedVariants returns the percent-encoded and decoded forms of word that
+// reach a different path on the wire than word itself (-encode-variants).
+// Spaces, for instance, are encoded by the HTTP client anyway, while ?, #
+// and ; only stay in the path when encoded.
+func encodedVariants(word string) []string {
+	candidates := []string{url.PathEscape(word)}
+	if decoded, err := url.PathUnescape(word); err == nil {
+		candidates = append(candidates, decoded)
+	}
+
+	seen := map[string]bool{wireForm(word): true}
+	var variants []string
+	for _, c := range candidates {
+		wire := wireForm(c)
+		if c == word || seen[wire] {
+			continue
+		}
+		seen[wire] = true
+		variants = append(variants, c)
+	}
+	return variants
+}
+
+// wireForm returns the request target sent for a path segment, or "" if the
+// segment does not form a valid URL
+func wireForm(segment string) string {
+	u, err := url.Parse("/" + segment)
+	if err != nil {
+		return ""
+	}
+	return u.RequestURI()
+}
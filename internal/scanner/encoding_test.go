@@ -0,0 +1,52 @@
+package scanner
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEncodedVariants(t *testing.T) {
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"admin", nil},
+		{"my file", nil}, // The client sends the space as %20 already
+		{"a?b", []string{"a%3Fb"}},
+		{"a#b", []string{"a%23b"}},
+		{"a;b", []string{"a%3Bb"}},
+		{"a/b", []string{"a%2Fb"}},
+		{"%2e%2e", []string{"%252e%252e", ".."}},
+		{"my%20file", []string{"my%2520file"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := encodedVariants(tt.word); !slices.Equal(got, tt.want) {
+				t.Errorf("encodedVariants(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeVariantsDirectoryURLs(t *testing.T) {
+	words := []string{"my file", "a?b", "admin"}
+	tests := []struct {
+		name   string
+		encode bool
+		want   []string
+	}{
+		{"off", false, []string{"/my file", "/a?b", "/admin"}},
+		{"on", true, []string{"/my file", "/a?b", "/a%3Fb", "/admin"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEngine(t, &Config{EncodeVariants: tt.encode})
+			got := directoryURLs(e, "http://target", words...)
+			if !slices.Equal(got, prefixAll("http://target", tt.want)) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package scanner
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -17,26 +22,144 @@ import (
 
 // Config holds scanner configuration
 type Config struct {
-	TargetURL    string
-	Words        []string
-	Threads      int
-	Timeout      time.Duration
-	UserAgent    string
-	Extensions   []string
-	Recursive    bool
-	MaxDepth     int
-	AddSlash     bool
-	FilterCodes  []int
-	ExcludeSizes []int64
-	StatusCodes  []int
+	TargetURL      string
+	Words          []string
+	WordStream     func(ctx context.Context) <-chan string // Streams words per phase instead of Words (huge lists)
+	WordCount      int                                     // Number of streamed words, for progress
+	Threads        int
+	Timeout        time.Duration
+	UserAgent      string
+	Extensions     []string
+	Recursive      bool
+	MaxDepth       int
+	PathDepths     map[string]int // Max depth overrides by path prefix (e.g. /api:20)
+	SkipExts       []string       // Skip recursion/file scanning in directories dominated by these
+	MinFindings    int            // Recurse only into directories whose parent yielded this many findings
+	MaxProbes      int            // Stop the scan after this many probed URLs, HEAD+GET pairs and retries counting once (0 = no limit)
+	MaxDirs        int            // Recurse into / scan files in at most this many directories, shallow first (0 = no limit)
+	Confirm        bool           // Re-request findings after the scan, only write those still reliable
+	AddSlash       bool
+	RootFirst      bool           // Probe and report the base URL before calibration
+	Prefixes       []string       // Also try each word with these prepended (-prefix)
+	Suffixes       []string       // Also try each word with these appended (-suffix)
+	MutateCase     bool           // Also try the lowercase, uppercase and Title-case forms of each word
+	DotFiles       bool           // Also probe .word and .word.ext variants
+	CaseEvade      bool           // Random letter case per probe (case-insensitive servers only)
+	EncodeVariants bool           // Also probe percent-encoded/decoded forms of special-character words
+	StemExpand     bool           // Probe found file stems with the other extensions of their profiles
+	Follow         bool           // Follow redirects, reporting where they lead (directory detection then sees no 301)
+	MaxRedirects   int            // Hops followed with Follow (0 = 10)
+	Unified        bool           // Scan directories and files in one pass instead of three phases
+	Backups        bool           // Probe backup variants (.bak, ~, .swp, ...) of the files found
+	ReportEmpty    bool           // Report 0-byte responses even if they look like soft 404s
+	ThreadsAuto    bool           // Tune concurrency to latency, between ThreadsMin and Threads
+	ThreadsMin     int            // Starting and lowest concurrency with ThreadsAuto
+	Rate           int            // Max requests per second across all workers (0 = unlimited)
+	Retries        int            // Extra attempts for timeouts, resets and 502/503/504
+	NoDotSkip      bool           // Probe dotted words (v1.2, api.v2) as directories too
+	APIExpand      bool           // Probe /v1, /v2, /v3, /latest under api, rest and graphql directories
+	QuietErrors    bool           // Never print request errors, even with VerboseErrors
+	VerboseErrors  bool           // Print the first request errors as they happen
+	TimingStats    bool           // Report response time percentiles in PrintStats
+	ProgressEvery  time.Duration  // Progress line refresh interval (0 = no progress line)
+	SizeMismatch   bool           // Annotate findings whose HEAD Content-Length disagrees with the GET body
+	HeadOnly       bool           // Never send verification GETs (no hash-based soft-404 detection)
+	MaxDownload    int64          // Body bytes read by verification GETs before going HEAD-only (0 = no limit)
+	Soft404Hash    bool           // Soft-404 requires a body hash match, not just an equal size
+	FilterURL      *regexp.Regexp // Candidate URLs matching this are never requested
+	RecurseOnly    *regexp.Regexp // Recurse only into directories whose name matches (nil = all)
+	Rules          []Rule         // Tagging rules applied to findings
+	ReportHashes   []string       // Body MD5s always reported, bypassing filters and soft-404 checks
+	FilterCodes    []int
+	WriteCodes     []int // Status codes written to the output file (DefaultWriteCodes if empty)
+	DirCodes       []int // Status codes meaning a directory, recursed into (empty = 200 and redirects)
+	RecurseCodes   []int // Status codes of the directories recursed into, overriding DirCodes
+	ExcludeSizes   []int64
+	MatchWords     []int    // Only report responses with these body word counts
+	FilterWords    []int    // Drop responses with these body word counts
+	FilterLines    []int    // Drop responses with these body line counts
+	MatchTypes     []string // Only report Content-Types containing one of these (case-insensitive)
+	FilterTypes    []string // Drop Content-Types containing one of these (case-insensitive)
+	Contains       string   // Only report responses whose body contains this text
+	ContainsNoCase bool     // Match Contains case-insensitively
+	SizeDev        float64  // Suppress responses within this % of the baseline size (0 = off)
+	MinConfidence  float64  // Suppress findings scoring below this confidence (0 = off)
+	StatusCodes    []int    // Report only these codes (-mc), FilterCodes still apply
+	ShowSource     bool
+	LineTemplate   string                 // Custom terminal line, e.g. "{status} {size} {url}"
+	EnumMethods    bool                   // Probe allowed HTTP methods on each finding after the scan
+	Fingerprint    bool                   // Identify the server and framework before brute-forcing
+	AutoExtensions bool                   // Add extensions matching the fingerprinted stack
+	FaviconHash    bool                   // Print the Shodan mmh3 hash of /favicon.ico in the header
+	Passive        bool                   // Only check paths from robots.txt, sitemaps, security.txt and homepage links
+	WellKnown      bool                   // Probe the standard /.well-known/ resources before Phase 1
+	Robots         bool                   // Probe the robots.txt Allow/Disallow paths before Phase 1
+	SaveResponses  bool                   // Findings carry their bodies for -save-responses
+	ExcludeHome    bool                   // Treat responses identical to the homepage as soft 404
+	RandomAgent    bool                   // Pick a browser User-Agent per request instead of UserAgent
+	CalMethod      string                 // HTTP method for calibration requests (default GET)
+	Method         string                 // Probe with this single request method instead of HEAD then GET (-X)
+	Data           string                 // Body sent with Method (-data)
+	RawRequest     *httpclient.RawRequest // Replay a raw request per word instead of path brute-forcing
+
+	// Circuit breaker: trip after MaxErrors consecutive failures (0 disables),
+	// then pause for ErrorCooldown or abort the host if no cool-down is set
+	MaxErrors     int
+	ErrorCooldown time.Duration
+
+	// Adaptive pacing: on 429/503 slow to one request every ReplayDelay
+	// (0 disables), then speed back up as clean responses resume
+	ReplayDelay time.Duration
+
+	// Requests in flight count against ConnLimit when engines share one (-hc)
+	ConnLimit ConnLimit
+
+	// Each worker sleeps after every request, a random duration within
+	// DelayMin-DelayMax (equal for a fixed delay, 0 disables)
+	DelayMin time.Duration
+	DelayMax time.Duration
+
+	Query     url.Values                  // Extra query parameters sent with every request
+	CacheBust bool                        // Random query parameter per request, 200s re-checked without it
+	Jar       http.CookieJar              // Session cookies (-cookie-jar)
+	Headers   http.Header                 // Custom headers sent with every request (-H)
+	Proxy     *url.URL                    // Route every request through this proxy (-proxy)
+	NTLM      *httpclient.NTLMCredentials // NTLM/Negotiate authentication
+
+	NoKeepAlive bool          // New connection per request
+	IdleTimeout time.Duration // Idle pooled connection lifetime (0 = default)
 }
 
+// ErrTargetDown is returned when the target cannot be reached at all, or the
+// circuit breaker aborted the scan
+var ErrTargetDown = errors.New("target down")
+
+// ErrUnresolvable is returned, along with ErrTargetDown, when the target host
+// name does not resolve
+var ErrUnresolvable = errors.New("cannot resolve")
+
+// targetDownError wraps the request error that showed the target is
+// unreachable, naming unresolvable hosts explicitly
+func targetDownError(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return fmt.Errorf("%w %s (%w)", ErrUnresolvable, dnsErr.Name, ErrTargetDown)
+	}
+	return fmt.Errorf("%w: %v", ErrTargetDown, err)
+}
+
+// maxErrorLines caps how many request errors are printed before they are
+// only counted and summarized at the end
+const maxErrorLines = 10
+
 // Engine is the main scanning engine - optimized for speed and accuracy
 type Engine struct {
 	config  *Config
 	client  *http.Client
 	printer *output.Printer
 	writer  *output.Writer
+	sinks   []output.Sink // Extra finding consumers (syslog, ...)
+	outputs []output.Sink // Extra output files written like writer (-oA)
 	ctx     context.Context
 	cancel  context.CancelFunc
 
@@ -45,6 +168,44 @@ type Engine struct {
 	found     uint64
 	errors    uint64
 	total     uint64 // Total URLs to scan for progress
+	unmatched uint64 // Findings dropped by the -contains filter
+	unwritten uint64 // Failed writes to the output files and sinks
+
+	// Sampled response times for -timing-stats
+	timings     []time.Duration
+	timingsSeen int64
+	timingsMux  sync.Mutex
+
+	// Verification GET body bytes, for -max-download (atomic)
+	downloaded     uint64
+	downloadCapped uint32
+	logged         uint64 // Errors printed so far (capped at maxErrorLines)
+	requested      uint64 // Probes handed out, for -maxreq (atomic)
+	budgetHit      uint32 // -maxreq stopped the scan (atomic)
+
+	// Circuit breaker state (atomic)
+	consecutiveErrors uint64
+	pausedUntil       int64        // UnixNano
+	abortErr          atomic.Value // error that aborted the scan, if any
+
+	// Adaptive pacing state (atomic, paceMux serializes requests while slowed)
+	paceDelay   int64 // Current inter-request delay in ns, 0 = full speed
+	cleanStreak uint64
+	paceMux     sync.Mutex
+
+	// -rate limiter shared by all workers (nil = unlimited)
+	limiter *rateLimiter
+
+	// -threads-auto concurrency gate (nil without it) and latency window
+	slots        chan struct{}
+	slotLimit    int64
+	slotPeak     int64
+	slotDebt     int64 // Slots to retire as they are released
+	latencySum   int64 // ns
+	latencyCount uint64
+
+	// Wordlist guard (words can be appended mid-scan)
+	wordsMux sync.RWMutex
 
 	// Deduplication
 	visited sync.Map
@@ -52,20 +213,55 @@ type Engine struct {
 	// Output deduplication (for file output)
 	outputURLs sync.Map
 
+	// Directories with an answer in -rc, the ones recursed into
+	recurseDirs sync.Map
+
+	// Per-directory extension distribution for -recurse-skip-ext
+	skipExts  map[string]bool
+	dirAssets map[string]*assetStats
+	assetsMux sync.Mutex
+
+	// Confirmed findings, kept for post-scan stages
+	findings    []output.Finding
+	findingsMux sync.Mutex
+
+	// File findings held back for the -confirm pass
+	held    []output.Finding
+	heldMux sync.Mutex
+
+	// Distinct findings per parent directory, for -recurse-min-findings
+	dirFindings    map[string]map[string]bool
+	dirFindingsMux sync.Mutex
+
 	// Discovered directories for recursive scanning
 	directories    []string
 	directoriesMux sync.Mutex
 
 	// Multiple baseline detection for better soft 404 handling
-	baselines []baseline
+	baselines    []baseline
+	baselineSize int64 // Most common calibration size, for -size-deviation
+
+	// Body hashes of scored results, for confidence hash uniqueness
+	hashCounts    map[string]int
+	hashCountsMux sync.Mutex
 
 	// Soft 404 size tracking - detect when many responses have same size
 	soft404Sizes    map[int64]int
 	soft404SizesMux sync.Mutex
 
 	// Filter maps for O(1) lookup
-	filterCodes map[int]bool
-	filterSizes map[int64]bool
+	filterCodes  map[int]bool
+	matchCodes   map[int]bool
+	dirCodes     map[int]bool
+	recurseCodes map[int]bool
+	writeCodes   map[int]bool
+	filterSizes  map[int64]bool
+	matchWords   map[int]bool
+	filterWords  map[int]bool
+	filterLines  map[int]bool
+
+	// Body hashes reported regardless of filters
+	reportHashes map[string]bool
 
 	startTime time.Time
 }
@@ -84,71 +280,289 @@ func NewEngine(cfg *Config, writer *output.Writer) *Engine {
 	for _, c := range cfg.FilterCodes {
 		filterCodes[c] = true
 	}
+	matchCodes := make(map[int]bool)
+	for _, c := range cfg.StatusCodes {
+		matchCodes[c] = true
+	}
+	dirCodes := make(map[int]bool)
+	for _, c := range cfg.DirCodes {
+		dirCodes[c] = true
+	}
+	recurseCodes := make(map[int]bool)
+	for _, c := range cfg.RecurseCodes {
+		recurseCodes[c] = true
+	}
+	writeCodes := make(map[int]bool)
+	codes := cfg.WriteCodes
+	if len(codes) == 0 {
+		codes = DefaultWriteCodes
+	}
+	for _, c := range codes {
+		writeCodes[c] = true
+	}
 	filterSizes := make(map[int64]bool)
 	for _, s := range cfg.ExcludeSizes {
 		filterSizes[s] = true
 	}
+	matchWords := make(map[int]bool)
+	for _, w := range cfg.MatchWords {
+		matchWords[w] = true
+	}
+	filterWords := make(map[int]bool)
+	for _, w := range cfg.FilterWords {
+		filterWords[w] = true
+	}
+	filterLines := make(map[int]bool)
+	for _, l := range cfg.FilterLines {
+		filterLines[l] = true
+	}
+	reportHashes := make(map[string]bool)
+	for _, h := range cfg.ReportHashes {
+		reportHashes[h] = true
+	}
+	skipExts := make(map[string]bool)
+	for _, ext := range cfg.SkipExts {
+		skipExts[strings.ToLower(strings.TrimPrefix(ext, "."))] = true
+	}
+
+	printer := output.NewPrinter(cfg.StatusCodes)
+	printer.SetShowSource(cfg.ShowSource)
+	if err := printer.SetTemplate(cfg.LineTemplate); err != nil {
+		utils.PrintWarning("Ignoring line template: %s", err)
+	}
 
-	return &Engine{
+	e := &Engine{
 		config:       cfg,
-		client:       httpclient.NewClient(&httpclient.Config{Timeout: cfg.Timeout, UserAgent: cfg.UserAgent}),
-		printer:      output.NewPrinter(cfg.StatusCodes),
+		printer:      printer,
 		writer:       writer,
 		ctx:          ctx,
 		cancel:       cancel,
 		directories:  make([]string, 0, 100),
 		baselines:    make([]baseline, 0, 5),
 		soft404Sizes: make(map[int64]int),
+		hashCounts:   make(map[string]int),
+		dirFindings:  make(map[string]map[string]bool),
 		filterCodes:  filterCodes,
+		matchCodes:   matchCodes,
+		dirCodes:     dirCodes,
+		recurseCodes: recurseCodes,
+		writeCodes:   writeCodes,
 		filterSizes:  filterSizes,
+		matchWords:   matchWords,
+		filterWords:  filterWords,
+		filterLines:  filterLines,
+		reportHashes: reportHashes,
+		skipExts:     skipExts,
+		dirAssets:    make(map[string]*assetStats),
 	}
+	if cfg.ThreadsAuto {
+		e.initSlots()
+	}
+	if cfg.Rate > 0 {
+		e.limiter = newRateLimiter(cfg.Rate)
+	}
+
+	clientConfig := &httpclient.Config{
+		Timeout:         cfg.Timeout,
+		FollowRedirects: cfg.Follow,
+		MaxRedirects:    cfg.MaxRedirects,
+		UserAgent:       cfg.UserAgent,
+		Query:           cfg.Query,
+		NTLM:            cfg.NTLM,
+		NoKeepAlive:     cfg.NoKeepAlive,
+		IdleTimeout:     cfg.IdleTimeout,
+		CacheBust:       cfg.CacheBust,
+		Jar:             cfg.Jar,
+		Headers:         cfg.Headers,
+		Proxy:           cfg.Proxy,
+	}
+	if e.limiter != nil || cfg.ConnLimit != nil {
+		clientConfig.Gate = e.gate
+	}
+	e.client = httpclient.NewClient(clientConfig)
+	return e
 }
 
 // Run starts the optimized 3-phase scanning process
 func (e *Engine) Run() error {
+	return e.RunContext(context.Background())
+}
+
+// RunContext runs the scan until it completes, Stop is called or ctx is done.
+// If ctx ends the scan early, its error is returned.
+func (e *Engine) RunContext(ctx context.Context) error {
+	// Derive the engine context from ctx: cancelling ctx stops the engine
+	stop := context.AfterFunc(ctx, e.cancel)
+	defer stop()
+	defer e.flushHeld()
+	defer close(e.startThreadTuner())
+	// Batches create an engine per target: free its idle pool once done
+	defer e.client.CloseIdleConnections()
+
+	if err := e.run(); err != nil {
+		return err
+	}
+	if err, ok := e.abortErr.Load().(error); ok {
+		return err
+	}
+	return ctx.Err()
+}
+
+// run executes the scan phases
+func (e *Engine) run() error {
 	baseURL := e.normalizeURL(e.config.TargetURL)
+	if e.config.RawRequest != nil {
+		baseURL = e.config.RawRequest.BaseURL()
+	}
 	e.startTime = time.Now()
 
 	// Print config
 	utils.PrintInfo("Target: %s", baseURL)
-	utils.PrintInfo("Threads: %d | Depth: %d | Recursive: %v", e.config.Threads, e.config.MaxDepth, e.config.Recursive)
+	threads := fmt.Sprint(e.config.Threads)
+	if e.config.ThreadsAuto {
+		threads = fmt.Sprintf("auto (%d-%d)", e.config.ThreadsMin, e.config.Threads)
+	}
+	utils.PrintInfo("Threads: %s | Depth: %d | Recursive: %v", threads, e.config.MaxDepth, e.config.Recursive)
 	if len(e.config.Extensions) > 0 {
 		utils.PrintInfo("Extensions: %s", strings.Join(e.config.Extensions, ", "))
 	}
+	if e.config.MutateCase {
+		words, mutated := e.caseInflation()
+		utils.PrintWarning("Case mutation: %d words become %d candidates per directory, requests grow accordingly", words, mutated)
+	}
+
+	// Confirm the target is alive and anchor the results with its root
+	if e.config.RootFirst {
+		if err := e.probeRoot(baseURL); err != nil {
+			return err
+		}
+	}
 
 	// Multi-point calibration for better soft 404 detection
-	e.calibrateMultiple(baseURL)
+	if err := e.calibrateMultiple(baseURL); err != nil {
+		return err
+	}
+	if e.config.ExcludeHome {
+		e.calibrateHomepage(baseURL)
+	}
+
+	if e.config.FaviconHash {
+		e.printFaviconHash(baseURL)
+	}
 
 	fmt.Println(strings.Repeat("─", 70))
 
+	// Raw request mode replaces the directory/file phases entirely
+	if raw := e.config.RawRequest; raw != nil {
+		utils.PrintInfo("Request template: %s %s", raw.Method, raw.Path)
+		e.scanTemplate()
+		return nil
+	}
+
+	// Passive mode replaces the brute-force phases
+	if e.config.Passive {
+		e.runPassive(baseURL)
+		return nil
+	}
+
+	if e.config.Fingerprint {
+		e.fingerprint(baseURL)
+	}
+
+	if e.config.WellKnown {
+		e.probeWellKnown(baseURL)
+	}
+
+	if e.config.Robots {
+		e.seedRobots(baseURL)
+	}
+
+	if e.config.Unified {
+		// Directories and files share one queue, without phase boundaries
+		utils.PrintInfo("Unified scan: directories and files in one pass")
+		e.scanUnified(baseURL)
+	} else {
+		e.scanPhases(baseURL)
+	}
+	if e.ctx.Err() != nil {
+		return nil
+	}
+
+	// Related extensions of the files found
+	if e.config.StemExpand {
+		e.expandStems()
+	}
+
+	// Backup copies of the files found
+	if e.config.Backups {
+		e.probeBackups()
+	}
+
+	// === Post-processing ===
+	if e.config.Confirm {
+		e.confirmFindings()
+	}
+	e.reportCanonicalAliases()
+	if e.config.EnumMethods {
+		e.enumerateMethods()
+	}
+
+	return nil
+}
+
+// scanPhases runs the three discovery phases: directories, recursion into
+// them, then files in every directory found
+func (e *Engine) scanPhases(baseURL string) {
 	// === PHASE 1: Fast directory discovery (HEAD requests) ===
 	utils.PrintInfo("Phase 1: Directory Discovery (fast)")
 	e.scanDirectoriesFast(baseURL, 0)
+	if e.config.APIExpand {
+		e.expandAPIs(0)
+	}
 
 	// === PHASE 2: Recursive subdirectory discovery ===
 	if e.config.Recursive && len(e.directories) > 0 {
-		for depth := 1; depth <= e.config.MaxDepth; depth++ {
+		recursed := 0
+		for depth := 1; depth <= e.maxRecursionDepth(); depth++ {
 			select {
 			case <-e.ctx.Done():
-				return nil
+				return
 			default:
 			}
 
-			// Get directories discovered at previous depth
-			dirs := e.getDirectoriesAtDepth(depth - 1)
+			// Get directories discovered at previous depth that may still recurse.
+			// Filtering here (not at discovery) keeps them in the file phase.
+			var dirs []string
+			for _, dir := range e.getDirectoriesAtDepth(depth - 1) {
+				if depth <= e.maxDepthFor(dir) && !e.isAssetDirectory(dir) && e.parentHasMinFindings(dir) &&
+					e.isRecurseTarget(dir) && e.isRecurseDir(dir) {
+					dirs = append(dirs, dir)
+				}
+			}
 			if len(dirs) == 0 {
 				break
 			}
+			dirs = e.limitDirs(dirs, recursed, "recursion")
+			if len(dirs) == 0 {
+				break
+			}
+			recursed += len(dirs)
 
 			utils.PrintInfo("Phase 2: Scanning %d directories at depth %d", len(dirs), depth)
 			for _, dir := range dirs {
 				select {
 				case <-e.ctx.Done():
-					return nil
+					return
 				default:
 				}
+				if e.budgetSpent() {
+					return
+				}
 				e.scanDirectoriesFast(dir, depth)
 			}
+			if e.config.APIExpand {
+				e.expandAPIs(depth)
+			}
 		}
 	}
 
@@ -156,24 +570,79 @@ func (e *Engine) Run() error {
 	if len(e.config.Extensions) > 0 {
 		utils.PrintInfo("Phase 3: File Discovery (%d extensions)", len(e.config.Extensions))
 		allDirs := e.getAllDirectories()
+		if e.config.MaxDirs > 0 {
+			allDirs = e.limitDirs(e.getDirectoriesByDepth(), 0, "file discovery")
+			sort.Strings(allDirs)
+		}
 		// Add base URL to scan for files
 		allDirs = append([]string{baseURL}, allDirs...)
 
 		for _, dir := range allDirs {
 			select {
 			case <-e.ctx.Done():
-				return nil
+				return
 			default:
 			}
+			if e.isAssetDirectory(dir) {
+				continue
+			}
 			e.scanFiles(dir)
 		}
 	}
+}
+
+// probeRoot requests the base URL and reports it as the first finding
+func (e *Engine) probeRoot(baseURL string) error {
+	rootURL := strings.TrimRight(baseURL, "/") + "/"
+	r := httpclient.RequestWithBody(e.client, rootURL, e.userAgent())
+	if r.Error != nil {
+		return targetDownError(r.Error)
+	}
+
+	// The root is alive either way; the filters only decide if it is shown
+	result := newResult(Job{URL: rootURL, Source: SourceRoot}, r, nil)
+	var score float64
+	if e.reportHashes[result.BodyHash] {
+		score = e.confidence(&result)
+	} else {
+		var keep bool
+		if score, keep = e.filterResult(&result); !keep || !e.reportable(&result) {
+			return nil
+		}
+	}
 
+	finding := &output.Finding{
+		URL:         rootURL,
+		StatusCode:  r.StatusCode,
+		Size:        r.Size,
+		IsDir:       true,
+		Source:      SourceRoot,
+		Time:        time.Now(),
+		Redirect:    r.RedirectURL,
+		Loop:        r.RedirectLoop,
+		ContentType: r.ContentType,
+		Duration:    r.Duration,
+		Confidence:  score,
+		Hash:        r.BodyHash,
+		Body:        r.Body,
+	}
+	if e.printer.PrintResult(finding) {
+		atomic.AddUint64(&e.found, 1)
+		e.recordFinding(finding)
+		if e.isReliableResult(r.StatusCode) && e.writesOutput() {
+			e.writeUniqueFinding(finding)
+		}
+	}
 	return nil
 }
 
-// calibrateMultiple performs multiple calibration requests for better soft 404 detection
-func (e *Engine) calibrateMultiple(baseURL string) {
+// appShell matches the markers of a single-page app shell: an empty root
+// element for the framework to mount on, or an ES module bundle
+var appShell = regexp.MustCompile(`(?i)<div\s+id\s*=\s*["'](?:root|app)["']\s*>\s*</div>|<script\b[^>]*\btype\s*=\s*["']module["']`)
+
+// calibrateMultiple performs multiple calibration requests for better soft 404 detection.
+// It fails with ErrTargetDown if none of them got a response.
+func (e *Engine) calibrateMultiple(baseURL string) error {
 	patterns := []string{
 		"xsearch_%d_calibration",
 		"nonexistent_%d_page",
@@ -184,24 +653,47 @@ func (e *Engine) calibrateMultiple(baseURL string) {
 	var mu sync.Mutex
 	hashCounts := make(map[string]int)
 	sizeCounts := make(map[int64]int)
+	okCount := 0
+	shell := false
+	var lastErr error
 
 	for _, pattern := range patterns {
 		wg.Add(1)
 		go func(p string) {
 			defer wg.Done()
-			randomURL := fmt.Sprintf("%s/%s", baseURL, fmt.Sprintf(p, time.Now().UnixNano()))
-			result := httpclient.RequestWithBody(e.client, randomURL, e.config.UserAgent)
+			result := e.calibrationRequest(baseURL, fmt.Sprintf(p, time.Now().UnixNano()))
 			if result.Error == nil && result.StatusCode != 0 {
 				mu.Lock()
 				hashCounts[result.BodyHash]++
 				sizeCounts[result.Size]++
+				if result.StatusCode == 200 {
+					okCount++
+				}
+				shell = shell || appShell.Match(result.Body)
 				e.baselines = append(e.baselines, baseline{hash: result.BodyHash, size: result.Size})
 				mu.Unlock()
+			} else if result.Error != nil {
+				mu.Lock()
+				lastErr = result.Error
+				mu.Unlock()
 			}
 		}(pattern)
 	}
 	wg.Wait()
 
+	if len(sizeCounts) == 0 && lastErr != nil {
+		return targetDownError(lastErr)
+	}
+
+	// Bodies of the miss responses start out shared for hashScore
+	e.hashCountsMux.Lock()
+	for h, c := range hashCounts {
+		if h != "" {
+			e.hashCounts[h] += c
+		}
+	}
+	e.hashCountsMux.Unlock()
+
 	// Find most common hash and size for reporting
 	var commonHash string
 	var commonSize int64
@@ -220,24 +712,92 @@ func (e *Engine) calibrateMultiple(baseURL string) {
 		}
 	}
 
+	e.baselineSize = commonSize
+
 	if len(e.baselines) > 0 && commonHash != "" {
 		utils.PrintInfo("Calibration: size=%d hash=%s (sampled %d)", commonSize, commonHash[:8], len(e.baselines))
+	} else if len(e.baselines) > 0 {
+		// Bodiless methods (HEAD) only give a size baseline
+		utils.PrintInfo("Calibration: size=%d (sampled %d, %s)", commonSize, len(e.baselines), e.calibrationMethod())
+	}
+
+	// Single-page apps serve the same app shell with 200 for every route;
+	// other catch-all servers are plain soft 404s that calibration handles
+	if len(e.baselines) == len(patterns) && okCount == len(patterns) && len(hashCounts) == 1 && commonHash != "" && shell {
+		utils.PrintWarning("Target looks like a JavaScript SPA: random paths return 200 with identical content")
+		utils.PrintWarning("Directory discovery will be unreliable; consider crawling the app or targeting its API endpoints")
+	}
+	return nil
+}
+
+// calibrateHomepage adds the homepage body as a hash-only baseline so that
+// catch-all routes serving the homepage are suppressed like soft 404s
+func (e *Engine) calibrateHomepage(baseURL string) {
+	result := httpclient.RequestWithBody(e.client, baseURL+"/", e.userAgent())
+	if result.Error != nil || result.BodyHash == "" {
+		utils.PrintWarning("Homepage calibration failed, -exclude-homepage disabled")
+		return
+	}
+
+	// Size 0 disables size matching: the homepage is identified by hash alone
+	e.baselines = append(e.baselines, baseline{hash: result.BodyHash})
+	utils.PrintInfo("Homepage baseline: size=%d hash=%s", result.Size, result.BodyHash[:8])
+}
+
+// calibrationRequest fetches a random, non-existent resource for baselining
+func (e *Engine) calibrationRequest(baseURL string, token string) *httpclient.Result {
+	if raw := e.config.RawRequest; raw != nil {
+		req, err := raw.Build(token)
+		if err != nil {
+			return &httpclient.Result{Error: err}
+		}
+		return httpclient.Send(e.client, req, true)
 	}
+
+	url := fmt.Sprintf("%s/%s", baseURL, token)
+	if e.config.Method != "" {
+		// Baselines must come from the same request as the probes
+		return httpclient.DataRequest(e.client, e.config.Method, url, e.userAgent(), e.config.Data, true)
+	}
+	switch method := e.calibrationMethod(); method {
+	case "GET":
+		return httpclient.RequestWithBody(e.client, url, e.userAgent())
+	case "HEAD":
+		return httpclient.HeadRequest(e.client, url, e.userAgent())
+	default:
+		return httpclient.MethodRequest(e.client, method, url, e.userAgent())
+	}
+}
+
+// userAgent returns the User-Agent of the next request: a random browser one
+// with -random-agent, the configured one otherwise
+func (e *Engine) userAgent() string {
+	if e.config.RandomAgent {
+		return httpclient.RandomUserAgent()
+	}
+	return e.config.UserAgent
+}
+
+// calibrationMethod returns the HTTP method used for calibration requests
+func (e *Engine) calibrationMethod() string {
+	if e.config.CalMethod == "" {
+		return "GET"
+	}
+	return strings.ToUpper(e.config.CalMethod)
 }
 
 // scanDirectoriesFast performs fast directory discovery using HEAD requests
 func (e *Engine) scanDirectoriesFast(basePath string, depth int) {
 	basePath = strings.TrimRight(basePath, "/")
 
-	// Build directory URLs only (no extensions)
-	urls := e.buildDirectoryURLs(basePath, depth)
-	if len(urls) == 0 {
+	// Build directory URLs only (no extensions), generated lazily
+	words, seen := e.wordSource()
+	if e.wordCount() == 0 {
 		return
 	}
+	urls := e.buildDirectoryURLs(words, basePath, depth)
 
-	totalURLs := uint64(len(urls))
-	atomic.StoreUint64(&e.total, totalURLs)
-	startProcessed := atomic.LoadUint64(&e.processed)
+	atomic.StoreUint64(&e.total, e.estimateURLs(e.wordCount(), e.dirURLsPerWord()))
 
 	jobs := make(chan Job, e.config.Threads*4)
 	results := make(chan Result, e.config.Threads*4)
@@ -255,89 +815,303 @@ func (e *Engine) scanDirectoriesFast(basePath string, depth int) {
 	go e.handleDirectoryResults(results, &resultWg, depth)
 
 	// Progress reporter
+	progressDone := e.startProgress(0)
+
+	// Send jobs
+	go e.feedJobs(jobs, urls, depth, seen, e.dirURLsPerWord(), func(added <-chan string) <-chan string {
+		return e.buildDirectoryURLs(added, basePath, depth)
+	})
+
+	wg.Wait()
+	close(results)
+	resultWg.Wait()
+	close(progressDone)
+}
+
+// startProgress launches the live progress line for the current batch, whose
+// request total is kept in e.total (it grows when words are added mid-scan).
+// Closing the returned channel stops the reporter and clears the line. Without
+// a progress interval nothing is printed.
+func (e *Engine) startProgress(startFound uint64) chan struct{} {
+	startProcessed := atomic.LoadUint64(&e.processed)
 	progressDone := make(chan struct{})
+	if e.config.ProgressEvery <= 0 {
+		return progressDone
+	}
+
 	go func() {
-		ticker := time.NewTicker(500 * time.Millisecond)
+		ticker := time.NewTicker(e.config.ProgressEvery)
 		defer ticker.Stop()
+
+		// Previous tick, for live throughput
+		var lastCount uint64
+		lastTick := time.Now()
+
 		for {
 			select {
 			case <-progressDone:
 				// Clear progress line
-				fmt.Printf("\r%s\r", strings.Repeat(" ", 60))
+				fmt.Printf("\r%s\r", strings.Repeat(" ", 80))
 				return
-			case <-ticker.C:
+			case now := <-ticker.C:
 				current := atomic.LoadUint64(&e.processed) - startProcessed
-				found := atomic.LoadUint64(&e.found)
-				pct := float64(current) / float64(totalURLs) * 100
+				found := atomic.LoadUint64(&e.found) - startFound
+				totalURLs := atomic.LoadUint64(&e.total)
+				pct := 100.0
+				if totalURLs > 0 {
+					pct = float64(current) / float64(totalURLs) * 100
+				}
 				if pct > 100 {
 					pct = 100
 				}
-				fmt.Printf("\r[%.1f%%] %d/%d requests | Found: %d", pct, current, totalURLs, found)
+
+				rps := float64(current-lastCount) / now.Sub(lastTick).Seconds()
+				lastCount, lastTick = current, now
+
+				eta := "--"
+				if rps > 0 && current < totalURLs {
+					eta = time.Duration(float64(totalURLs-current) / rps * float64(time.Second)).Round(time.Second).String()
+				}
+
+				fmt.Printf("\r[%.1f%%] %d/%d requests | %.0f req/s | ETA: %s | Found: %d   ", pct, current, totalURLs, rps, eta, found)
 			}
 		}
 	}()
 
-	// Send jobs
+	return progressDone
+}
+
+// feedJobs queues the phase's URLs, then keeps queueing URLs for words added
+// mid-scan (wordlist reload) until none are pending. Closes jobs when done.
+func (e *Engine) feedJobs(jobs chan<- Job, urls <-chan string, depth int, seen int, perWord int, build func(added <-chan string) <-chan string) {
+	defer close(jobs)
+
+	e.feedURLs(func(job Job) bool {
+		select {
+		case <-e.ctx.Done():
+			return false
+		case jobs <- job:
+			return true
+		}
+	}, urls, depth, seen, perWord, build)
+}
+
+// feedURLs passes the URLs to send as wordlist jobs, then the URLs built for
+// words added mid-scan, until none are pending or send returns false
+func (e *Engine) feedURLs(send func(job Job) bool, urls <-chan string, depth int, seen int, perWord int, build func(added <-chan string) <-chan string) {
+	for {
+		for u := range urls {
+			if e.budgetSpent() || !send(Job{URL: u, Depth: depth, Source: SourceWordlist}) {
+				return
+			}
+		}
+
+		added := e.wordsSince(seen)
+		if len(added) == 0 {
+			return
+		}
+		seen += len(added)
+		urls = build(e.sendWords(added))
+		atomic.AddUint64(&e.total, e.estimateURLs(len(added), perWord))
+	}
+}
+
+// wordSource returns the words of a phase as a channel, streamed from disk
+// when configured, and how many loaded words it covers (for feedJobs)
+func (e *Engine) wordSource() (<-chan string, int) {
+	words := e.wordsSince(0)
+	if e.config.WordStream != nil {
+		return e.config.WordStream(e.ctx), len(words)
+	}
+	return e.sendWords(words), len(words)
+}
+
+// wordCount returns the number of words a phase iterates
+func (e *Engine) wordCount() int {
+	e.wordsMux.RLock()
+	defer e.wordsMux.RUnlock()
+
+	if e.config.WordStream != nil {
+		return e.config.WordCount + len(e.config.Words)
+	}
+	return len(e.config.Words)
+}
+
+// sendWords streams a word slice until it is exhausted or the scan stops
+func (e *Engine) sendWords(words []string) <-chan string {
+	out := make(chan string, 256)
 	go func() {
-	jobLoop:
-		for _, u := range urls {
+		defer close(out)
+		for _, w := range words {
 			select {
 			case <-e.ctx.Done():
-				break jobLoop
-			case jobs <- Job{URL: u, Depth: depth}:
+				return
+			case out <- w:
 			}
 		}
-		close(jobs)
 	}()
+	return out
+}
 
-	wg.Wait()
-	close(results)
-	resultWg.Wait()
-	close(progressDone)
+// estimateURLs is the progress total for words expanding into perWord URLs.
+// URLs are generated lazily, so already visited ones are still counted.
+func (e *Engine) estimateURLs(words int, perWord int) uint64 {
+	return uint64(words * perWord)
 }
 
-// buildDirectoryURLs generates directory URLs only (no file extensions)
-func (e *Engine) buildDirectoryURLs(basePath string, depth int) []string {
-	var urls []string
+// dirURLsPerWord is the number of directory candidates built per word
+func (e *Engine) dirURLsPerWord() int {
+	n := len(e.wordVariants("word"))
+	if e.config.AddSlash {
+		n *= 2
+	}
+	return n
+}
 
-	for _, word := range e.config.Words {
-		word = strings.TrimSpace(word)
-		if word == "" || strings.HasPrefix(word, "#") {
-			continue
-		}
-		word = strings.TrimPrefix(word, "/")
+// fileURLsPerWord is the number of file candidates built per word
+func (e *Engine) fileURLsPerWord() int {
+	return len(e.wordVariants("word")) * len(e.config.Extensions)
+}
 
-		// Skip words that look like files (have extensions)
-		if strings.Contains(word, ".") {
-			continue
-		}
+// wordsSince returns a snapshot of the words appended after the first n
+func (e *Engine) wordsSince(n int) []string {
+	e.wordsMux.RLock()
+	defer e.wordsMux.RUnlock()
+
+	if n >= len(e.config.Words) {
+		return nil
+	}
+	return e.config.Words[n:len(e.config.Words):len(e.config.Words)]
+}
+
+// AddWords appends words to the active wordlist while scanning.
+// Running phases queue them immediately; later phases include them as usual.
+func (e *Engine) AddWords(words []string) {
+	e.wordsMux.Lock()
+	e.config.Words = append(e.config.Words, words...)
+	e.wordsMux.Unlock()
+}
+
+// buildDirectoryURLs lazily generates directory URLs only (no file extensions)
+func (e *Engine) buildDirectoryURLs(words <-chan string, basePath string, depth int) <-chan string {
+	urls := make(chan string, 256)
+
+	go func() {
+		defer close(urls)
+		for word := range words {
+			word = strings.TrimSpace(word)
+			if word == "" || strings.HasPrefix(word, "#") {
+				continue
+			}
+			word = strings.TrimPrefix(word, "/")
+			if strings.Contains(word, extToken) {
+				// Extension templates are file candidates only
+				continue
+			}
+
+			for _, variant := range e.wordVariants(word) {
+				// Skip words that look like files (have extensions) unless
+				// NoDotSkip is set. Dotfiles like .git or .env only have a
+				// leading dot and are kept.
+				if !e.config.NoDotSkip && strings.Contains(strings.TrimPrefix(variant, "."), ".") {
+					continue
+				}
 
-		fullURL := fmt.Sprintf("%s/%s", basePath, word)
+				fullURL := fmt.Sprintf("%s/%s", basePath, variant)
 
-		// Skip if visited
-		if _, visited := e.visited.Load(fullURL); visited {
-			continue
+				// Skip if visited or excluded
+				if e.isExcludedURL(fullURL) || !e.markVisited(fullURL, depth) {
+					continue
+				}
+				if !e.emitURL(urls, fullURL) {
+					return
+				}
+
+				// Also test with trailing slash for directory confirmation
+				if e.config.AddSlash {
+					slashURL := fullURL + "/"
+					if !e.isExcludedURL(slashURL) && e.markVisited(slashURL, depth) && !e.emitURL(urls, slashURL) {
+						return
+					}
+				}
+			}
 		}
-		e.visited.Store(fullURL, depth)
+	}()
+
+	return urls
+}
+
+// emitURL sends a generated URL, reporting false once the scan is stopped
+func (e *Engine) emitURL(urls chan<- string, url string) bool {
+	select {
+	case <-e.ctx.Done():
+		return false
+	case urls <- url:
+		return true
+	}
+}
+
+// markVisited claims a candidate URL and reports whether it was new. The
+// check and store are a single atomic step, so builders running concurrently
+// (e.g. words added mid-scan) can never queue the same URL twice.
+func (e *Engine) markVisited(url string, depth int) bool {
+	_, visited := e.visited.LoadOrStore(url, depth)
+	return !visited
+}
+
+// isExcludedURL reports whether a candidate URL matches -filter-regex-url
+func (e *Engine) isExcludedURL(url string) bool {
+	return e.config.FilterURL != nil && e.config.FilterURL.MatchString(url)
+}
+
+// isRecurseTarget reports whether the name of a directory matches -recurse-only
+func (e *Engine) isRecurseTarget(dir string) bool {
+	if e.config.RecurseOnly == nil {
+		return true
+	}
+	name := strings.TrimRight(dir, "/")
+	return e.config.RecurseOnly.MatchString(name[strings.LastIndex(name, "/")+1:])
+}
+
+// wordVariants returns the candidates probed for a wordlist entry
+func (e *Engine) wordVariants(word string) []string {
+	variants := []string{word}
+
+	// Prefixed and suffixed forms (dev-admin, admin-old, ...)
+	if len(e.config.Prefixes) > 0 || len(e.config.Suffixes) > 0 {
+		variants = e.affixedWords(word)
+	}
 
-		urls = append(urls, fullURL)
+	// Case permutations for case-sensitive servers
+	if e.config.MutateCase {
+		for _, v := range variants {
+			variants = append(variants, caseVariants(v)...)
+		}
+	}
 
-		// Also test with trailing slash for directory confirmation
-		if e.config.AddSlash {
-			slashURL := fullURL + "/"
-			if _, visited := e.visited.Load(slashURL); !visited {
-				e.visited.Store(slashURL, depth)
-				urls = append(urls, slashURL)
+	// Hidden file variant (.word) for plain wordlists
+	if e.config.DotFiles {
+		for _, v := range variants {
+			if !strings.HasPrefix(v, ".") {
+				variants = append(variants, "."+v)
 			}
 		}
 	}
 
-	return urls
+	// Percent-encoded/decoded forms of words with special characters
+	if e.config.EncodeVariants {
+		for _, v := range variants {
+			variants = append(variants, encodedVariants(v)...)
+		}
+	}
+
+	return variants
 }
 
 // workerFast uses HEAD requests for faster directory discovery
 func (e *Engine) workerFast(jobs <-chan Job, results chan<- Result, wg *sync.WaitGroup) {
 	defer wg.Done()
+	delay := e.workerDelay()
 
 	for {
 		select {
@@ -347,137 +1121,366 @@ func (e *Engine) workerFast(jobs <-chan Job, results chan<- Result, wg *sync.Wai
 			if !ok {
 				return
 			}
-			// Use HEAD request first (faster)
-			r := httpclient.HeadRequest(e.client, job.URL, e.config.UserAgent)
-
-			// For successful responses, verify with GET to check soft 404
-			needsVerification := r.Error == nil &&
-				r.StatusCode != 404 &&
-				!e.filterCodes[r.StatusCode] &&
-				(r.StatusCode == 200 || r.StatusCode == 301 || r.StatusCode == 302 || r.StatusCode == 403)
-
-			var bodyHash string
-			var size int64 = r.Size
-
-			if needsVerification {
-				// Verify with GET request to check body hash
-				fullResult := httpclient.RequestWithBody(e.client, job.URL, e.config.UserAgent)
-				if fullResult.Error == nil {
-					bodyHash = fullResult.BodyHash
-					size = fullResult.Size
-				}
+			result, ok := e.probeDirectoryJob(job)
+			if !ok {
+				return
 			}
 
 			select {
 			case <-e.ctx.Done():
 				return
-			case results <- Result{
-				URL:        r.URL,
-				StatusCode: r.StatusCode,
-				Size:       size,
-				BodyHash:   bodyHash,
-				Depth:      job.Depth,
-				Error:      r.Error,
-			}:
+			case results <- result:
+			}
+			if !delay() {
+				return
 			}
 		}
 	}
 }
 
+// probeDirectoryJob requests a directory candidate, HEAD first and GET to
+// verify interesting answers. ok is false once the scan is stopped.
+func (e *Engine) probeDirectoryJob(job Job) (result Result, ok bool) {
+	if !e.waitCircuit() || !e.takeBudget() {
+		return result, false
+	}
+	release, ok := e.pace()
+	if !ok {
+		return result, false
+	}
+	defer release()
+
+	probe := e.probeURL(job.URL)
+	if e.config.Method != "" {
+		return e.probeWithMethod(job, probe), true
+	}
+	ua := e.userAgent() // The same for HEAD and its verification GET
+
+	// Use HEAD request first (faster)
+	r := e.withRetry(func() *httpclient.Result {
+		return httpclient.HeadRequest(e.client, probe, ua)
+	})
+	e.recordOutcome(r.Error)
+	e.recordPace(r.StatusCode, r.Error)
+	e.observeLatency(r.Duration, r.Error)
+
+	// For successful responses, verify with GET to check soft 404
+	// (-report-hashes also checks the bodies of filtered codes)
+	needsVerification := r.Error == nil && !e.headOnly() &&
+		r.StatusCode != 404 &&
+		(!e.filterCodes[r.StatusCode] || len(e.reportHashes) > 0) &&
+		(r.StatusCode == 200 || r.StatusCode == 301 || r.StatusCode == 302 || r.StatusCode == 403 ||
+			e.needsBody())
+
+	var fullResult *httpclient.Result
+	if needsVerification {
+		// Verify with GET request to check body hash
+		fullResult = e.withRetry(func() *httpclient.Result {
+			return httpclient.RequestWithBody(e.client, probe, ua)
+		})
+		e.countDownload(fullResult)
+	}
+	result = newResult(job, r, fullResult)
+	if e.config.CacheBust && result.Error == nil && result.StatusCode == 200 {
+		result.BustArtifact = e.isCacheBustArtifact(probe)
+	}
+	return result, true
+}
+
 // handleDirectoryResults processes directory scan results
 func (e *Engine) handleDirectoryResults(results <-chan Result, wg *sync.WaitGroup, depth int) {
 	defer wg.Done()
 
 	for r := range results {
-		atomic.AddUint64(&e.processed, 1)
+		e.handleDirectoryResult(r, depth)
+	}
+}
 
-		if r.Error != nil {
-			atomic.AddUint64(&e.errors, 1)
-			continue
-		}
+// handleDirectoryResult reports a directory scan result found at depth. It
+// returns the directory stored for recursion, if the result is one.
+func (e *Engine) handleDirectoryResult(r Result, depth int) string {
+	atomic.AddUint64(&e.processed, 1)
+	e.checkBudget()
 
-		// Skip 404 and filtered codes
-		if r.StatusCode == 404 || e.filterCodes[r.StatusCode] {
-			continue
+	if r.Error != nil {
+		atomic.AddUint64(&e.errors, 1)
+		e.logError(r)
+		return ""
+	}
+	e.recordTiming(r.Duration)
+
+	var score float64
+	report := true
+	if e.reportHashes[r.BodyHash] {
+		// Listed hashes are always reported, bypassing every filter
+		score = e.confidence(&r)
+	} else {
+		var keep bool
+		if score, keep = e.filterResult(&r); !keep {
+			return ""
 		}
+		report = e.reportable(&r)
+	}
 
-		// Skip server errors for recursive scanning (often false positives)
-		if r.StatusCode >= 500 {
-			continue
+	// Determine if it's a directory (redirect loops never recurse)
+	isDir := !r.Loop && e.isDirectory(r.URL, r.StatusCode)
+
+	// Tag and print result
+	r.Tags = e.tagsFor(r.URL, r.StatusCode)
+	finding := &output.Finding{
+		URL:         r.URL,
+		StatusCode:  r.StatusCode,
+		Size:        r.Size,
+		IsDir:       isDir,
+		Depth:       depth,
+		Source:      r.Source,
+		Time:        time.Now(),
+		Redirect:    r.RedirectURL,
+		Chain:       r.Chain,
+		Loop:        r.Loop,
+		Tags:        r.Tags,
+		ContentType: r.ContentType,
+		Duration:    r.Duration,
+		Confidence:  score,
+		Hash:        r.BodyHash,
+		Body:        r.Body,
+	}
+	if e.config.SizeMismatch && r.SizeDiffers {
+		finding.SizeDiffers = true
+		finding.HeadSize = r.HeadSize
+	}
+	if report {
+		if !e.printer.PrintResult(finding) {
+			return ""
 		}
-
-		// Skip filtered sizes
-		if e.filterSizes[r.Size] {
-			continue
+		atomic.AddUint64(&e.found, 1)
+		e.recordFinding(finding)
+		e.trackAsset(r.URL, r.ContentType)
+		e.countDirFinding(r.URL)
+
+		// Write to file - only reliable results, deduplicated
+		if e.isReliableResult(r.StatusCode) && e.writesOutput() {
+			e.writeUniqueFinding(finding)
 		}
+	}
 
-		// Skip soft 404 (check against all baselines)
-		if e.isSoft404(r.BodyHash, r.Size) {
-			continue
+	// Store directory for recursive scanning - only for successful responses
+	// Don't recurse into 4xx errors as they're usually not real directories
+	if isDir && (e.isDirStatus(r.StatusCode) || e.recurseCodes[r.StatusCode]) {
+		url := strings.TrimRight(r.URL, "/")
+		if len(e.recurseCodes) == 0 || e.recurseCodes[r.StatusCode] {
+			e.recurseDirs.Store(url, true)
 		}
+		e.trackListing(url, r.Body)
+		e.directoriesMux.Lock()
+		e.directories = append(e.directories, fmt.Sprintf("%d:%s", depth, url))
+		e.directoriesMux.Unlock()
+		return url
+	}
+	return ""
+}
 
-		// Dynamic soft 404 detection for 403/401 with repetitive sizes
-		if e.trackSoft404Size(r.Size, r.StatusCode) {
-			continue
-		}
+// filterResult applies the response filters and soft-404 detection shared by
+// the result handlers. It returns the confidence score of results to report.
+func (e *Engine) filterResult(r *Result) (float64, bool) {
+	// Skip 404 (unless matched explicitly) and filtered codes
+	if (r.StatusCode == 404 && !e.matchCodes[404]) || e.filterCodes[r.StatusCode] {
+		return 0, false
+	}
 
-		// Determine if it's a directory
-		isDir := e.isDirectory(r.URL, r.StatusCode)
+	// Skip 200s produced by the cache buster's query string
+	if r.BustArtifact {
+		return 0, false
+	}
 
-		// Print result
-		if e.printer.PrintResult(r.URL, r.StatusCode, r.Size, isDir, depth) {
-			atomic.AddUint64(&e.found, 1)
+	// Skip server errors (usually false positives) unless matched explicitly
+	if r.StatusCode >= 500 && !e.matchCodes[r.StatusCode] {
+		return 0, false
+	}
 
-			// Write to file - only reliable results, deduplicated
-			if e.isReliableResult(r.StatusCode) && e.writer.IsEnabled() {
-				e.writeUniqueURL(r.URL)
-			}
+	// Skip filtered sizes
+	if e.filterSizes[r.Size] {
+		return 0, false
+	}
 
-			// Store directory for recursive scanning - only for successful responses
-			// Don't recurse into 4xx errors as they're usually not real directories
-			if isDir && (r.StatusCode == 200 || r.StatusCode == 301 || r.StatusCode == 302 || r.StatusCode == 307 || r.StatusCode == 308) {
-				url := strings.TrimRight(r.URL, "/")
-				e.directoriesMux.Lock()
-				e.directories = append(e.directories, fmt.Sprintf("%d:%s", depth, url))
-				e.directoriesMux.Unlock()
-			}
-		}
+	// Keep only size outliers relative to the baseline
+	if e.withinSizeDeviation(r.Size) {
+		return 0, false
+	}
+
+	// Keep only matching word counts (requires a body)
+	if len(e.matchWords) > 0 && (r.BodyHash == "" || !e.matchWords[r.Words]) {
+		return 0, false
+	}
+
+	// Skip filtered word and line counts (requires a body)
+	if r.BodyHash != "" && (e.filterWords[r.Words] || e.filterLines[r.Lines]) {
+		return 0, false
 	}
+
+	// -report-empty: 0-byte responses skip the soft 404 and confidence
+	// heuristics (explicit filters above still apply), and so do the real
+	// 404s that -mc asked for
+	trusted := (e.config.ReportEmpty && r.Size == 0) || r.StatusCode == 404
+
+	// Skip soft 404 (check against all baselines)
+	if !trusted && e.isSoft404(r.BodyHash, r.Size) {
+		return 0, false
+	}
+
+	// Dynamic soft 404 detection for 403/401 with repetitive sizes
+	if !trusted && e.trackSoft404Size(r.Size, r.StatusCode) {
+		return 0, false
+	}
+
+	// Aggregate signals into a confidence score, then count the body for
+	// the results that follow
+	score := e.confidence(r)
+	e.countHash(r.BodyHash)
+	if !trusted && score < e.config.MinConfidence {
+		return 0, false
+	}
+
+	// Keep only bodies containing the -contains text
+	if !e.bodyContains(r.Body) {
+		atomic.AddUint64(&e.unmatched, 1)
+		return 0, false
+	}
+
+	return score, true
+}
+
+// reportable applies the filters that only decide what is reported, not
+// what exists: a directory they hide is still recursed into
+func (e *Engine) reportable(r *Result) bool {
+	// Keep only matched codes (-mc), -fc excluding within them
+	if len(e.matchCodes) > 0 && !e.matchCodes[r.StatusCode] {
+		return false
+	}
+
+	// Content-Type filters (the GET's when HEAD had none, see newResult)
+	if len(e.config.MatchTypes) > 0 && !containsAny(r.ContentType, e.config.MatchTypes) {
+		return false
+	}
+	return !containsAny(r.ContentType, e.config.FilterTypes)
+}
+
+// logError prints a request error with -verbose, unless -quiet-errors is set
+// or the maxErrorLines cap is reached; errors not printed are summarized in
+// PrintStats
+func (e *Engine) logError(r Result) {
+	if !e.config.VerboseErrors || e.config.QuietErrors {
+		return
+	}
+	if atomic.AddUint64(&e.logged, 1) > maxErrorLines {
+		return
+	}
+	fmt.Println()
+	utils.PrintWarning("Request error: %v", r.Error)
+}
+
+// AddSink registers an extra consumer for every reported finding.
+// Must be called before Run.
+func (e *Engine) AddSink(sink output.Sink) {
+	e.sinks = append(e.sinks, sink)
 }
 
-// isReliableResult returns true if the status code indicates a reliable finding
+// AddOutput registers an extra output file fed like the -o one: reliable
+// findings only, deduplicated and, with -confirm, confirmed. Must be called
+// before Run.
+func (e *Engine) AddOutput(out output.Sink) {
+	e.outputs = append(e.outputs, out)
+}
+
+// recordFinding keeps a confirmed finding for post-scan stages and
+// forwards it to the registered sinks
+func (e *Engine) recordFinding(f *output.Finding) {
+	stored := *f
+	stored.Body = nil
+	e.findingsMux.Lock()
+	e.findings = append(e.findings, stored)
+	e.findingsMux.Unlock()
+
+	for _, sink := range e.sinks {
+		e.outputError(sink.WriteFinding(f))
+	}
+}
+
+// getFindings returns a copy of all confirmed findings
+func (e *Engine) getFindings() []output.Finding {
+	e.findingsMux.Lock()
+	defer e.findingsMux.Unlock()
+
+	findings := make([]output.Finding, len(e.findings))
+	copy(findings, e.findings)
+	return findings
+}
+
+// DefaultWriteCodes are the status codes written to the output file unless
+// -write-codes says otherwise
+var DefaultWriteCodes = []int{200, 301, 302, 307, 308, 401, 403}
+
+// isReliableResult returns true if the status code is one written to the
+// output file (-write-codes), whatever is displayed
 func (e *Engine) isReliableResult(statusCode int) bool {
-	// Only write truly valid results to output file
-	return statusCode == 200 || statusCode == 301 || statusCode == 302 ||
-		statusCode == 307 || statusCode == 308 || statusCode == 403 || statusCode == 401
+	return e.writeCodes[statusCode]
 }
 
-// writeUniqueURL writes URL to output file, avoiding duplicates (normalizes trailing slash)
-func (e *Engine) writeUniqueURL(url string) {
+// writeUniqueFinding writes a finding to the output file, avoiding duplicates (normalizes trailing slash)
+func (e *Engine) writeUniqueFinding(f *output.Finding) {
 	// Normalize URL (remove trailing slash for deduplication)
-	normalizedURL := strings.TrimRight(url, "/")
+	normalizedURL := strings.TrimRight(f.URL, "/")
 
 	// Check if already written
 	if _, exists := e.outputURLs.LoadOrStore(normalizedURL, true); exists {
 		return
 	}
 
+	if e.config.Confirm && e.config.RawRequest == nil {
+		e.holdFinding(f)
+		return
+	}
+
 	// Write the original URL
-	e.writer.WriteURL(url)
+	e.writeOutput(f)
+}
+
+// writesOutput reports whether findings go to an output file
+func (e *Engine) writesOutput() bool {
+	return e.writer.IsEnabled() || len(e.outputs) > 0
+}
+
+// writeOutput writes a finding to the -o file and the extra output files
+func (e *Engine) writeOutput(f *output.Finding) {
+	e.outputError(e.writer.WriteFinding(f))
+	for _, out := range e.outputs {
+		e.outputError(out.WriteFinding(f))
+	}
+}
+
+// outputError counts a failed write to an output file or sink; the first one
+// is printed, the total is reported by PrintStats
+func (e *Engine) outputError(err error) {
+	if err == nil {
+		return
+	}
+	if atomic.AddUint64(&e.unwritten, 1) == 1 {
+		fmt.Println()
+		utils.PrintWarning("Output error: %v (further errors are only counted)", err)
+	}
 }
 
 // scanFiles scans for files with extensions in a directory
 func (e *Engine) scanFiles(basePath string) {
 	basePath = strings.TrimRight(basePath, "/")
 
-	urls := e.buildFileURLs(basePath)
-	if len(urls) == 0 {
+	words, seen := e.wordSource()
+	if e.wordCount() == 0 {
 		return
 	}
+	urls := e.buildFileURLs(words, basePath)
 
-	totalURLs := uint64(len(urls))
-	atomic.StoreUint64(&e.total, totalURLs)
-	startProcessed := atomic.LoadUint64(&e.processed)
+	atomic.StoreUint64(&e.total, e.estimateURLs(e.wordCount(), e.fileURLsPerWord()))
 	startFound := atomic.LoadUint64(&e.found)
 
 	jobs := make(chan Job, e.config.Threads*4)
@@ -496,40 +1499,12 @@ func (e *Engine) scanFiles(basePath string) {
 	go e.handleFileResults(results, &resultWg)
 
 	// Progress reporter
-	progressDone := make(chan struct{})
-	go func() {
-		ticker := time.NewTicker(500 * time.Millisecond)
-		defer ticker.Stop()
-		for {
-			select {
-			case <-progressDone:
-				// Clear progress line
-				fmt.Printf("\r%s\r", strings.Repeat(" ", 60))
-				return
-			case <-ticker.C:
-				current := atomic.LoadUint64(&e.processed) - startProcessed
-				found := atomic.LoadUint64(&e.found) - startFound
-				pct := float64(current) / float64(totalURLs) * 100
-				if pct > 100 {
-					pct = 100
-				}
-				fmt.Printf("\r[%.1f%%] %d/%d requests | Found: %d", pct, current, totalURLs, found)
-			}
-		}
-	}()
+	progressDone := e.startProgress(startFound)
 
 	// Send jobs
-	go func() {
-	jobLoop:
-		for _, u := range urls {
-			select {
-			case <-e.ctx.Done():
-				break jobLoop
-			case jobs <- Job{URL: u, Depth: 0}:
-			}
-		}
-		close(jobs)
-	}()
+	go e.feedJobs(jobs, urls, 0, seen, e.fileURLsPerWord(), func(added <-chan string) <-chan string {
+		return e.buildFileURLs(added, basePath)
+	})
 
 	wg.Wait()
 	close(results)
@@ -537,26 +1512,60 @@ func (e *Engine) scanFiles(basePath string) {
 	close(progressDone)
 }
 
-// buildFileURLs generates file URLs with extensions
-func (e *Engine) buildFileURLs(basePath string) []string {
-	var urls []string
+// extToken is the dirsearch wordlist placeholder for the -x extensions
+const extToken = "%EXT%"
+
+// splitExtTemplate splits an extension template into the stem that word
+// variants apply to and the tail holding the placeholder: index.%EXT% gives
+// index and .%EXT%, so -suffix -old yields index-old.php
+func splitExtTemplate(word string) (stem, tail string) {
+	i := strings.Index(word, extToken)
+	stem, tail = word[:i], word[i:]
+	if strings.HasSuffix(stem, ".") {
+		stem, tail = stem[:len(stem)-1], "."+tail
+	}
+	return stem, tail
+}
 
-	for _, word := range e.config.Words {
-		word = strings.TrimSpace(word)
-		if word == "" || strings.HasPrefix(word, "#") {
-			continue
-		}
-		word = strings.TrimPrefix(word, "/")
+// buildFileURLs lazily generates file URLs with extensions
+func (e *Engine) buildFileURLs(words <-chan string, basePath string) <-chan string {
+	urls := make(chan string, 256)
 
-		// Add each extension
-		for _, ext := range e.config.Extensions {
-			extURL := fmt.Sprintf("%s/%s.%s", basePath, word, ext)
-			if _, visited := e.visited.Load(extURL); !visited {
-				e.visited.Store(extURL, 0)
-				urls = append(urls, extURL)
+	go func() {
+		defer close(urls)
+		for word := range words {
+			word = strings.TrimSpace(word)
+			if word == "" || strings.HasPrefix(word, "#") {
+				continue
+			}
+			word = strings.TrimPrefix(word, "/")
+
+			// dirsearch-style templates (index.%EXT%) take each extension
+			// in place instead of a suffix
+			if strings.Contains(word, extToken) {
+				stem, tail := splitExtTemplate(word)
+				for _, variant := range e.wordVariants(stem) {
+					for _, ext := range e.config.Extensions {
+						extURL := fmt.Sprintf("%s/%s%s", basePath, variant, strings.ReplaceAll(tail, extToken, ext))
+						if !e.isExcludedURL(extURL) && e.markVisited(extURL, 0) && !e.emitURL(urls, extURL) {
+							return
+						}
+					}
+				}
+				continue
+			}
+
+			// Add each extension
+			for _, variant := range e.wordVariants(word) {
+				for _, ext := range e.config.Extensions {
+					extURL := fmt.Sprintf("%s/%s.%s", basePath, variant, ext)
+					if !e.isExcludedURL(extURL) && e.markVisited(extURL, 0) && !e.emitURL(urls, extURL) {
+						return
+					}
+				}
 			}
 		}
-	}
+	}()
 
 	return urls
 }
@@ -564,6 +1573,7 @@ func (e *Engine) buildFileURLs(basePath string) []string {
 // workerFiles handles file discovery with GET requests
 func (e *Engine) workerFiles(jobs <-chan Job, results chan<- Result, wg *sync.WaitGroup) {
 	defer wg.Done()
+	delay := e.workerDelay()
 
 	for {
 		select {
@@ -573,89 +1583,312 @@ func (e *Engine) workerFiles(jobs <-chan Job, results chan<- Result, wg *sync.Wa
 			if !ok {
 				return
 			}
-			// Use HEAD for speed, only GET if potentially interesting
-			r := httpclient.HeadRequest(e.client, job.URL, e.config.UserAgent)
-
-			var bodyHash string
-			var size int64 = r.Size
-
-			// Verify interesting results
-			if r.Error == nil && r.StatusCode != 404 && !e.filterCodes[r.StatusCode] {
-				fullResult := httpclient.RequestWithBody(e.client, job.URL, e.config.UserAgent)
-				if fullResult.Error == nil {
-					bodyHash = fullResult.BodyHash
-					size = fullResult.Size
-				}
+			result, ok := e.probeFileJob(job)
+			if !ok {
+				return
 			}
 
 			select {
 			case <-e.ctx.Done():
 				return
-			case results <- Result{
-				URL:        r.URL,
-				StatusCode: r.StatusCode,
-				Size:       size,
-				BodyHash:   bodyHash,
-				Depth:      job.Depth,
-				Error:      r.Error,
-			}:
+			case results <- result:
+			}
+			if !delay() {
+				return
 			}
 		}
 	}
 }
 
+// probeFileJob requests a file candidate, HEAD first and GET to verify
+// interesting answers. ok is false once the scan is stopped.
+func (e *Engine) probeFileJob(job Job) (result Result, ok bool) {
+	if !e.waitCircuit() || !e.takeBudget() {
+		return result, false
+	}
+	release, ok := e.pace()
+	if !ok {
+		return result, false
+	}
+	defer release()
+
+	probe := e.probeURL(job.URL)
+	if e.config.Method != "" {
+		return e.probeWithMethod(job, probe), true
+	}
+	ua := e.userAgent() // The same for HEAD and its verification GET
+
+	// Use HEAD for speed, only GET if potentially interesting
+	r := e.withRetry(func() *httpclient.Result {
+		return httpclient.HeadRequest(e.client, probe, ua)
+	})
+	e.recordOutcome(r.Error)
+	e.recordPace(r.StatusCode, r.Error)
+	e.observeLatency(r.Duration, r.Error)
+
+	// Verify interesting results
+	var fullResult *httpclient.Result
+	if r.Error == nil && !e.headOnly() && r.StatusCode != 404 &&
+		(!e.filterCodes[r.StatusCode] || len(e.reportHashes) > 0) {
+		fullResult = e.withRetry(func() *httpclient.Result {
+			return httpclient.RequestWithBody(e.client, probe, ua)
+		})
+		e.countDownload(fullResult)
+	}
+	result = newResult(job, r, fullResult)
+	if e.config.CacheBust && result.Error == nil && result.StatusCode == 200 {
+		result.BustArtifact = e.isCacheBustArtifact(probe)
+	}
+	return result, true
+}
+
+// probeWithMethod probes with the -X method in a single request, which
+// replaces the HEAD/GET pair and reads the body unless downloads are off
+func (e *Engine) probeWithMethod(job Job, probe string) Result {
+	readBody := !e.headOnly()
+	r := e.withRetry(func() *httpclient.Result {
+		return httpclient.DataRequest(e.client, e.config.Method, probe, e.userAgent(), e.config.Data, readBody)
+	})
+	e.recordOutcome(r.Error)
+	e.recordPace(r.StatusCode, r.Error)
+	e.observeLatency(r.Duration, r.Error)
+	if readBody {
+		e.countDownload(r)
+	}
+
+	result := newResult(job, r, nil)
+	if e.config.CacheBust && result.Error == nil && result.StatusCode == 200 {
+		result.BustArtifact = e.isCacheBustArtifact(probe)
+	}
+	return result
+}
+
 // handleFileResults processes file scan results
 func (e *Engine) handleFileResults(results <-chan Result, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for r := range results {
-		atomic.AddUint64(&e.processed, 1)
+		e.handleFileResult(r)
+	}
+}
 
-		if r.Error != nil {
-			atomic.AddUint64(&e.errors, 1)
-			continue
-		}
+// handleFileResult reports a file scan result
+func (e *Engine) handleFileResult(r Result) {
+	atomic.AddUint64(&e.processed, 1)
+	e.checkBudget()
 
-		// Skip 404 and filtered codes
-		if r.StatusCode == 404 || e.filterCodes[r.StatusCode] {
-			continue
+	if r.Error != nil {
+		atomic.AddUint64(&e.errors, 1)
+		e.logError(r)
+		return
+	}
+	e.recordTiming(r.Duration)
+
+	var score float64
+	if e.reportHashes[r.BodyHash] {
+		// Listed hashes are always reported, bypassing every filter
+		score = e.confidence(&r)
+	} else {
+		var keep bool
+		if score, keep = e.filterResult(&r); !keep || !e.reportable(&r) {
+			return
 		}
+	}
 
-		// Skip server errors (usually false positives)
-		if r.StatusCode >= 500 {
-			continue
+	// Tag and print result - files are not directories
+	r.Tags = e.tagsFor(r.URL, r.StatusCode)
+	finding := &output.Finding{
+		URL:         r.URL,
+		StatusCode:  r.StatusCode,
+		Size:        r.Size,
+		Source:      r.Source,
+		Time:        time.Now(),
+		Redirect:    r.RedirectURL,
+		Chain:       r.Chain,
+		Loop:        r.Loop,
+		Tags:        r.Tags,
+		ContentType: r.ContentType,
+		Duration:    r.Duration,
+		Confidence:  score,
+		Hash:        r.BodyHash,
+		Body:        r.Body,
+	}
+	if e.config.SizeMismatch && r.SizeDiffers {
+		finding.SizeDiffers = true
+		finding.HeadSize = r.HeadSize
+	}
+	if e.printer.PrintResult(finding) {
+		atomic.AddUint64(&e.found, 1)
+		e.recordFinding(finding)
+		e.trackAsset(r.URL, r.ContentType)
+
+		// Write to file - only reliable results, deduplicated
+		if e.isReliableResult(r.StatusCode) && e.writesOutput() {
+			e.writeUniqueFinding(finding)
 		}
+	}
+}
 
-		// Skip filtered sizes
-		if e.filterSizes[r.Size] {
-			continue
-		}
+// scanTemplate replays the raw request template once per word
+func (e *Engine) scanTemplate() {
+	raw := e.config.RawRequest
 
-		// Skip soft 404
-		if e.isSoft404(r.BodyHash, r.Size) {
-			continue
-		}
+	words, _ := e.wordSource()
+	if e.wordCount() == 0 {
+		return
+	}
+
+	totalURLs := uint64(e.wordCount())
+	atomic.StoreUint64(&e.total, totalURLs)
+
+	jobs := make(chan Job, e.config.Threads*4)
+	results := make(chan Result, e.config.Threads*4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < e.config.Threads; i++ {
+		wg.Add(1)
+		go e.workerTemplate(jobs, results, &wg)
+	}
 
-		// Dynamic soft 404 detection for 403/401 with repetitive sizes
-		if e.trackSoft404Size(r.Size, r.StatusCode) {
-			continue
+	var resultWg sync.WaitGroup
+	resultWg.Add(1)
+	go e.handleFileResults(results, &resultWg)
+
+	progressDone := e.startProgress(0)
+
+	go func() {
+	jobLoop:
+		for w := range words {
+			w = strings.TrimSpace(w)
+			if w == "" || strings.HasPrefix(w, "#") {
+				continue
+			}
+			select {
+			case <-e.ctx.Done():
+				break jobLoop
+			case jobs <- Job{URL: raw.URL(w), Word: w, Source: SourceWordlist}:
+			}
 		}
+		close(jobs)
+	}()
 
-		// Files are not directories
-		isDir := false
+	wg.Wait()
+	close(results)
+	resultWg.Wait()
+	close(progressDone)
+}
 
-		// Print result
-		if e.printer.PrintResult(r.URL, r.StatusCode, r.Size, isDir, 0) {
-			atomic.AddUint64(&e.found, 1)
+// workerTemplate sends the templated request with the job word substituted
+func (e *Engine) workerTemplate(jobs <-chan Job, results chan<- Result, wg *sync.WaitGroup) {
+	defer wg.Done()
+	delay := e.workerDelay()
 
-			// Write to file - only reliable results, deduplicated
-			if e.isReliableResult(r.StatusCode) && e.writer.IsEnabled() {
-				e.writeUniqueURL(r.URL)
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case job, ok := <-jobs:
+			if !ok {
+				return
+			}
+			if !e.waitCircuit() {
+				return
+			}
+			release, ok := e.pace()
+			if !ok {
+				return
+			}
+
+			var r *httpclient.Result
+			req, err := e.config.RawRequest.Build(job.Word)
+			if err != nil {
+				r = &httpclient.Result{URL: job.URL, Error: err}
+			} else {
+				r = e.withRetry(func() *httpclient.Result {
+					return httpclient.Send(e.client, httpclient.Rewound(req), true)
+				})
+				e.recordOutcome(r.Error)
+				e.recordPace(r.StatusCode, r.Error)
+				e.observeLatency(r.Duration, r.Error)
+			}
+			release()
+
+			select {
+			case <-e.ctx.Done():
+				return
+			case results <- newResult(job, r, r):
+			}
+			if !delay() {
+				return
 			}
 		}
 	}
 }
 
+// recordOutcome feeds the circuit breaker with the outcome of a request.
+// Any success resets the consecutive error count.
+func (e *Engine) recordOutcome(err error) {
+	if e.config.MaxErrors <= 0 {
+		return
+	}
+	if err == nil {
+		atomic.StoreUint64(&e.consecutiveErrors, 0)
+		return
+	}
+
+	// Trip exactly once when the threshold is crossed
+	if atomic.AddUint64(&e.consecutiveErrors, 1) != uint64(e.config.MaxErrors) {
+		return
+	}
+
+	if e.config.ErrorCooldown <= 0 {
+		fmt.Println()
+		utils.PrintError("%d consecutive errors, target looks down - aborting", e.config.MaxErrors)
+		e.abortErr.Store(fmt.Errorf("%w: %d consecutive errors", ErrTargetDown, e.config.MaxErrors))
+		e.cancel()
+		return
+	}
+
+	fmt.Println()
+	utils.PrintWarning("%d consecutive errors, pausing for %s", e.config.MaxErrors, e.config.ErrorCooldown)
+	atomic.StoreInt64(&e.pausedUntil, time.Now().Add(e.config.ErrorCooldown).UnixNano())
+	atomic.StoreUint64(&e.consecutiveErrors, 0)
+}
+
+// waitCircuit blocks while the circuit breaker is open.
+// Returns false if the scan was stopped while waiting.
+func (e *Engine) waitCircuit() bool {
+	wait := time.Until(time.Unix(0, atomic.LoadInt64(&e.pausedUntil)))
+	if wait <= 0 {
+		return true
+	}
+	select {
+	case <-e.ctx.Done():
+		return false
+	case <-time.After(wait):
+		return true
+	}
+}
+
+// needsBody reports whether every candidate response must be fetched with GET
+// because a filter depends on the body
+func (e *Engine) needsBody() bool {
+	return len(e.matchWords) > 0 || len(e.filterWords) > 0 || len(e.filterLines) > 0 || e.config.Soft404Hash || e.config.Contains != "" || len(e.reportHashes) > 0 ||
+		e.config.SaveResponses
+}
+
+// bodyContains reports whether body contains the -contains text (always true
+// when the filter is off)
+func (e *Engine) bodyContains(body []byte) bool {
+	if e.config.Contains == "" {
+		return true
+	}
+	if e.config.ContainsNoCase {
+		return bytes.Contains(bytes.ToLower(body), []byte(strings.ToLower(e.config.Contains)))
+	}
+	return bytes.Contains(body, []byte(e.config.Contains))
+}
+
 // isSoft404 checks if response matches any baseline (soft 404)
 func (e *Engine) isSoft404(hash string, size int64) bool {
 	// Check against calibration baselines
@@ -664,14 +1897,27 @@ func (e *Engine) isSoft404(hash string, size int64) bool {
 		if hash != "" && b.hash == hash {
 			return true
 		}
-		// Match by exact size (common for error pages)
-		if b.size > 0 && size == b.size {
+		// Match by exact size (common for error pages) unless hashes are required
+		if !e.config.Soft404Hash && b.size > 0 && size == b.size {
 			return true
 		}
 	}
 	return false
 }
 
+// withinSizeDeviation reports whether a size falls inside the -size-deviation
+// band around the calibration baseline size
+func (e *Engine) withinSizeDeviation(size int64) bool {
+	if e.config.SizeDev <= 0 || e.baselineSize <= 0 || size < 0 {
+		return false
+	}
+	diff := float64(size - e.baselineSize)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff/float64(e.baselineSize)*100 <= e.config.SizeDev
+}
+
 // trackSoft404Size tracks response sizes for dynamic soft 404 detection
 // Returns true if this size has been seen too many times (likely soft 404)
 func (e *Engine) trackSoft404Size(size int64, statusCode int) bool {
@@ -705,6 +1951,84 @@ func (e *Engine) trackSoft404Size(size int64, statusCode int) bool {
 	return false
 }
 
+// maxDepthFor returns the recursion depth limit for a directory URL:
+// the longest matching -d-path prefix, or the global max depth
+func (e *Engine) maxDepthFor(dirURL string) int {
+	path := dirURL
+	if u, err := url.Parse(dirURL); err == nil {
+		path = u.Path
+	}
+	path = strings.TrimRight(path, "/") + "/"
+
+	limit := e.config.MaxDepth
+	longest := -1
+	for prefix, depth := range e.config.PathDepths {
+		p := "/" + strings.Trim(prefix, "/") + "/"
+		if strings.HasPrefix(path, p) && len(p) > longest {
+			longest = len(p)
+			limit = depth
+		}
+	}
+	return limit
+}
+
+// countDirFinding records a directory-phase finding in its parent directory.
+// "dir" and "dir/" count once.
+func (e *Engine) countDirFinding(url string) {
+	child := strings.TrimRight(url, "/")
+	parent := parentDir(child)
+
+	e.dirFindingsMux.Lock()
+	defer e.dirFindingsMux.Unlock()
+	if e.dirFindings[parent] == nil {
+		e.dirFindings[parent] = make(map[string]bool)
+	}
+	e.dirFindings[parent][child] = true
+}
+
+// parentHasMinFindings reports whether the directory dir was discovered in
+// yielded at least MinFindings findings. Directories failing this are still
+// scanned for files, they are just not recursed into.
+func (e *Engine) parentHasMinFindings(dir string) bool {
+	if e.config.MinFindings <= 0 {
+		return true
+	}
+
+	e.dirFindingsMux.Lock()
+	defer e.dirFindingsMux.Unlock()
+	return len(e.dirFindings[parentDir(strings.TrimRight(dir, "/"))]) >= e.config.MinFindings
+}
+
+// containsAny reports whether s contains one of the substrings,
+// case-insensitively
+func containsAny(s string, substrings []string) bool {
+	s = strings.ToLower(s)
+	for _, sub := range substrings {
+		if strings.Contains(s, strings.ToLower(sub)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parentDir returns the URL of the directory containing url (no trailing slash)
+func parentDir(url string) string {
+	if idx := strings.LastIndex(url, "/"); idx > 0 {
+		return url[:idx]
+	}
+	return url
+}
+
+// maxRecursionDepth returns the deepest level any directory may reach
+func (e *Engine) maxRecursionDepth() int {
+	max := e.config.MaxDepth
+	for _, depth := range e.config.PathDepths {
+		if depth > max {
+			max = depth
+		}
+	}
+	return max
+}
 
 // getDirectoriesAtDepth returns directories found at a specific depth
 func (e *Engine) getDirectoriesAtDepth(depth int) []string {
@@ -741,8 +2065,47 @@ func (e *Engine) getAllDirectories() []string {
 	return dirs
 }
 
+// getDirectoriesByDepth returns all discovered directories, shallowest first
+// and in discovery order within a depth
+func (e *Engine) getDirectoriesByDepth() []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	for depth := 0; depth <= e.maxRecursionDepth(); depth++ {
+		for _, dir := range e.getDirectoriesAtDepth(depth) {
+			if !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// limitDirs caps dirs to what is left of the -max-dirs budget after used
+// directories, warning when the limit is reached. dirs must be ordered by
+// priority.
+func (e *Engine) limitDirs(dirs []string, used int, phase string) []string {
+	if e.config.MaxDirs <= 0 {
+		return dirs
+	}
+	left := e.config.MaxDirs - used
+	if left <= 0 {
+		return nil // Already warned when the limit was reached
+	}
+	if len(dirs) <= left {
+		return dirs
+	}
+	utils.PrintWarning("Directory limit reached (%d): skipping %d directories in %s, coverage is truncated",
+		e.config.MaxDirs, len(dirs)-left, phase)
+	return dirs[:left]
+}
+
 // isDirectory determines if a path is likely a directory
 func (e *Engine) isDirectory(url string, statusCode int) bool {
+	// -dir-codes: other codes are never directories
+	if len(e.dirCodes) > 0 && !e.dirCodes[statusCode] {
+		return false
+	}
 	// Redirects typically indicate directories
 	if statusCode == 301 || statusCode == 302 || statusCode == 307 || statusCode == 308 {
 		return true
@@ -762,6 +2125,27 @@ func (e *Engine) isDirectory(url string, statusCode int) bool {
 	return false
 }
 
+// isDirStatus reports whether a directory answering statusCode is kept for
+// recursion and the file phase: the -dir-codes, or by default 200 and
+// redirects. The -rc codes are kept too, see isRecurseDir.
+func (e *Engine) isDirStatus(statusCode int) bool {
+	if len(e.dirCodes) > 0 {
+		return e.dirCodes[statusCode]
+	}
+	return statusCode == 200 || statusCode == 301 || statusCode == 302 || statusCode == 307 || statusCode == 308
+}
+
+// isRecurseDir reports whether a kept directory is recursed into: with -rc,
+// only once one of its answers had one of those codes. The others are still
+// scanned for files.
+func (e *Engine) isRecurseDir(dir string) bool {
+	if len(e.recurseCodes) == 0 {
+		return true
+	}
+	_, ok := e.recurseDirs.Load(strings.TrimRight(dir, "/"))
+	return ok
+}
+
 // normalizeURL ensures proper URL format
 func (e *Engine) normalizeURL(url string) string {
 	url = strings.TrimRight(url, "/")
@@ -776,6 +2160,17 @@ func (e *Engine) Stop() {
 	e.cancel()
 }
 
+// Stats returns the processed request, finding and error counters
+func (e *Engine) Stats() (processed, found, errors uint64) {
+	return atomic.LoadUint64(&e.processed), atomic.LoadUint64(&e.found), atomic.LoadUint64(&e.errors)
+}
+
+// Directories returns the sorted directories discovered so far. It is safe to
+// call while the scan runs; the returned slice is a copy.
+func (e *Engine) Directories() []string {
+	return e.getAllDirectories()
+}
+
 // PrintStats prints final statistics
 func (e *Engine) PrintStats() {
 	duration := time.Since(e.startTime)
@@ -786,6 +2181,34 @@ func (e *Engine) PrintStats() {
 	fmt.Println(strings.Repeat("─", 70))
 	utils.PrintInfo("Completed in %s", duration.Round(time.Millisecond))
 	utils.PrintInfo("Requests: %d | Found: %d | Errors: %d", processed, found, errors)
+	if atomic.LoadUint32(&e.budgetHit) == 1 {
+		utils.PrintWarning("Scan truncated by the probe budget (-maxreq %d URLs)", e.config.MaxProbes)
+	}
+	if unwritten := atomic.LoadUint64(&e.unwritten); unwritten > 0 {
+		utils.PrintWarning("%d finding writes failed, output files or sinks are incomplete", unwritten)
+	}
+	if e.config.TimingStats {
+		e.printTimingStats()
+	}
+	if e.config.ThreadsAuto {
+		e.printThreadStats()
+	}
+
+	// Errors counted but not printed
+	shown := atomic.LoadUint64(&e.logged)
+	if shown > maxErrorLines {
+		shown = maxErrorLines
+	}
+	if !e.config.VerboseErrors || e.config.QuietErrors {
+		shown = 0
+	}
+	if suppressed := errors - shown; suppressed > 0 {
+		utils.PrintWarning("%d errors suppressed", suppressed)
+	}
+
+	if e.config.Contains != "" {
+		utils.PrintInfo("Content filter: %d findings without %q dropped", atomic.LoadUint64(&e.unmatched), e.config.Contains)
+	}
 
 	// Print directories found
 	dirs := e.getAllDirectories()
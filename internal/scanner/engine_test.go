@@ -0,0 +1,148 @@
+package scanner
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Fastdev75/xsearch/internal/output"
+)
+
+// newTestEngine returns an engine without an output file
+func newTestEngine(t *testing.T, cfg *Config) *Engine {
+	t.Helper()
+	if cfg.Threads == 0 {
+		cfg.Threads = 2
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	writer, err := output.NewWriter("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewEngine(cfg, writer)
+}
+
+func TestDirectoryProbeCountsOnce(t *testing.T) {
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/ok":
+			w.Write([]byte("real content"))
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		path     string
+		gets     int // Verification GETs expected after the HEAD
+		findings int
+	}{
+		{"/ok", 1, 1},
+		{"/moved", 1, 1},
+		{"/forbidden", 1, 1},
+		{"/missing", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			e := newTestEngine(t, &Config{TargetURL: srv.URL})
+			e.probeDirectories([]string{srv.URL + tt.path}, 0, "test")
+
+			mu.Lock()
+			heads, gets := hits["HEAD "+tt.path], hits["GET "+tt.path]
+			mu.Unlock()
+			if heads != 1 || gets != tt.gets {
+				t.Errorf("requests = %d HEAD, %d GET, want 1 HEAD, %d GET", heads, gets, tt.gets)
+			}
+			if processed, _, _ := e.Stats(); processed != 1 {
+				t.Errorf("processed = %d, want 1", processed)
+			}
+			if n := len(e.getFindings()); n != tt.findings {
+				t.Errorf("findings = %d, want %d", n, tt.findings)
+			}
+		})
+	}
+}
+
+// directoryURLs collects the directory candidates built for words under base
+func directoryURLs(e *Engine, base string, words ...string) []string {
+	in := make(chan string, len(words))
+	for _, w := range words {
+		in <- w
+	}
+	close(in)
+
+	var urls []string
+	for u := range e.buildDirectoryURLs(in, base, 0) {
+		urls = append(urls, u)
+	}
+	return urls
+}
+
+func TestDottedDirectoryWords(t *testing.T) {
+	words := []string{"admin", "v1.2", "api.v2", ".git"}
+	tests := []struct {
+		name      string
+		noDotSkip bool
+		want      []string
+	}{
+		{"default skips dotted words", false, []string{"/admin", "/.git"}},
+		{"-no-dot-skip probes them", true, []string{"/admin", "/v1.2", "/api.v2", "/.git"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEngine(t, &Config{NoDotSkip: tt.noDotSkip})
+			got := directoryURLs(e, "http://target", words...)
+			if !slices.Equal(got, prefixAll("http://target", tt.want)) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// prefixAll prepends base to every path
+func prefixAll(base string, paths []string) []string {
+	urls := make([]string, len(paths))
+	for i, p := range paths {
+		urls[i] = base + p
+	}
+	return urls
+}
+
+func TestAppShell(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"react root", `<body><div id="root"></div><script src="/main.js"></script></body>`, true},
+		{"vue app", "<body><div id='app'>\n</div></body>", true},
+		{"module bundle", `<script type="module" crossorigin src="/assets/index.js"></script>`, true},
+		{"soft 404 page", `<html><body><h1>Page not found</h1></body></html>`, false},
+		{"filled root", `<div id="root"><p>Server-rendered</p></div>`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appShell.MatchString(tt.body); got != tt.want {
+				t.Errorf("appShell.MatchString(%q) = %v, want %v", tt.body, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package scanner
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// probeURL returns the URL actually requested for a job URL. With -case-evade
+// the letters of the last path segment get a random case per probe (aDmIn),
+// which only finds anything on case-insensitive servers (IIS, some configs).
+// Results keep the job URL.
+func (e *Engine) probeURL(url string) string {
+	if !e.config.CaseEvade {
+		return url
+	}
+	return randomCase(url)
+}
+
+// randomCase randomizes the case of the letters in the last path segment of
+// url, leaving percent escapes untouched
+func randomCase(url string) string {
+	trimmed := strings.TrimRight(url, "/")
+	start := strings.LastIndex(trimmed, "/") + 1
+	if scheme := strings.Index(url, "://"); scheme >= 0 && start <= scheme+3 {
+		return url // No path
+	}
+
+	b := []byte(url)
+	for i := start; i < len(trimmed); i++ {
+		if b[i] == '%' {
+			i += 2
+			continue
+		}
+		c := b[i]
+		if (c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') && rand.Intn(2) == 0 {
+			b[i] ^= 0x20 // Flip ASCII case
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,103 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math/bits"
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/httpclient"
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// faviconSignatures maps Shodan favicon hashes (http.favicon.hash) to the
+// product serving them
+var faviconSignatures = map[int32]signature{
+	116323821:   {"", "Spring Boot", "java"},
+	81586312:    {"", "Jenkins", "java"},
+	-297069493:  {"", "Apache Tomcat", "java"},
+	1278323681:  {"", "GitLab", ""},
+	2123863676:  {"", "Grafana", ""},
+	-305179312:  {"", "Atlassian Confluence", "java"},
+	981867722:   {"", "Atlassian Jira", "java"},
+	1485257654:  {"", "SonarQube", "java"},
+	-1010568750: {"", "phpMyAdmin", "php"},
+	-335242539:  {"", "F5 BIG-IP", ""},
+	945408572:   {"", "Fortinet FortiGate", ""},
+}
+
+// printFaviconHash fetches /favicon.ico and prints its Shodan-compatible
+// mmh3 hash with the product it belongs to, if known
+func (e *Engine) printFaviconHash(baseURL string) {
+	r := httpclient.RequestWithBody(e.client, strings.TrimRight(baseURL, "/")+"/favicon.ico", e.userAgent())
+	if r.Error != nil || r.StatusCode != 200 || len(r.Body) == 0 ||
+		strings.Contains(r.ContentType, "html") || e.isSoft404(r.BodyHash, r.Size) {
+		utils.PrintInfo("Favicon: none")
+		return
+	}
+
+	hash := faviconHash(r.Body)
+	if sig, ok := faviconSignatures[hash]; ok {
+		utils.PrintSuccess("Favicon: %d (%s)", hash, sig.tech)
+		return
+	}
+	utils.PrintInfo("Favicon: %d (unknown, search http.favicon.hash:%d)", hash, hash)
+}
+
+// faviconHash computes the favicon hash used by Shodan: the signed 32-bit
+// MurmurHash3 of the base64 encoding with a newline every 76 characters
+// (Python's base64.encodebytes)
+func faviconHash(data []byte) int32 {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var b strings.Builder
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteByte('\n')
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	b.WriteByte('\n')
+	return int32(murmur3(0, []byte(b.String())))
+}
+
+// murmur3 is MurmurHash3 x86 32-bit
+func murmur3(seed uint32, data []byte) uint32 {
+	const c1, c2 = 0xcc9e2d51, 0x1b873593
+
+	h := seed
+	n := len(data) / 4 * 4
+	for i := 0; i < n; i += 4 {
+		k := binary.LittleEndian.Uint32(data[i:])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	var k uint32
+	tail := data[n:]
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(data))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
@@ -0,0 +1,183 @@
+package scanner
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/httpclient"
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// signature maps an indicator to a technology and its extension profile
+type signature struct {
+	match   string // Case-insensitive substring (headers) or exact name (cookies)
+	tech    string
+	profile string // Key into ExtensionProfiles, empty if none applies
+}
+
+// headerSignatures are matched against Server and X-Powered-By style headers
+var headerSignatures = map[string][]signature{
+	"Server": {
+		{"nginx", "nginx", ""},
+		{"apache", "Apache", ""},
+		{"microsoft-iis", "IIS", "dotnet"},
+		{"litespeed", "LiteSpeed", ""},
+		{"caddy", "Caddy", ""},
+		{"openresty", "OpenResty", ""},
+		{"jetty", "Jetty", "java"},
+		{"tomcat", "Tomcat", "java"},
+		{"gunicorn", "Gunicorn", ""},
+		{"werkzeug", "Werkzeug", ""},
+	},
+	"X-Powered-By": {
+		{"php", "PHP", "php"},
+		{"asp.net", "ASP.NET", "dotnet"},
+		{"express", "Express", "js"},
+		{"next.js", "Next.js", "js"},
+		{"servlet", "Java Servlet", "java"},
+		{"jsp", "JSP", "java"},
+	},
+	"X-AspNet-Version": {{"", "ASP.NET", "dotnet"}},
+	"X-Generator":      {{"drupal", "Drupal", "php"}},
+}
+
+// cookieSignatures identify frameworks by their session cookie names
+var cookieSignatures = []signature{
+	{"PHPSESSID", "PHP", "php"},
+	{"JSESSIONID", "Java", "java"},
+	{"ASP.NET_SessionId", "ASP.NET", "dotnet"},
+	{"ASPSESSIONID", "Classic ASP", "dotnet"},
+	{"laravel_session", "Laravel", "php"},
+	{"ci_session", "CodeIgniter", "php"},
+	{"connect.sid", "Express", "js"},
+	{"csrftoken", "Django", ""},
+	{"wordpress_test_cookie", "WordPress", "php"},
+}
+
+// pathSignatures are framework-specific paths probed during fingerprinting
+var pathSignatures = []signature{
+	{"/wp-login.php", "WordPress", "php"},
+	{"/administrator/", "Joomla", "php"},
+	{"/user/login", "Drupal", "php"},
+	{"/manager/html", "Tomcat Manager", "java"},
+	{"/WEB-INF/web.xml", "Java", "java"},
+	{"/trace.axd", "ASP.NET", "dotnet"},
+}
+
+// fingerprint identifies the web server and framework from the homepage
+// headers, session cookies and well-known paths. With AutoExtensions the
+// matching extension profiles are added to the file phase.
+func (e *Engine) fingerprint(baseURL string) {
+	var techs []string
+	profiles := make(map[string]bool)
+	seen := make(map[string]bool)
+	add := func(sig signature) {
+		if !seen[sig.tech] {
+			seen[sig.tech] = true
+			techs = append(techs, sig.tech)
+		}
+		if sig.profile != "" {
+			profiles[sig.profile] = true
+		}
+	}
+
+	home := httpclient.Request(e.client, baseURL, e.userAgent())
+	if home.Error == nil {
+		for _, sig := range matchHeaders(home.Header) {
+			add(sig)
+		}
+	}
+
+	for _, sig := range pathSignatures {
+		select {
+		case <-e.ctx.Done():
+			return
+		default:
+		}
+		r := httpclient.RequestWithBody(e.client, strings.TrimRight(baseURL, "/")+sig.match, e.userAgent())
+		if r.Error != nil || e.isSoft404(r.BodyHash, r.Size) {
+			continue
+		}
+		if r.StatusCode == 200 || r.StatusCode == 401 || r.StatusCode == 403 {
+			add(sig)
+		}
+		for _, s := range matchHeaders(r.Header) {
+			add(s)
+		}
+	}
+
+	favicon := httpclient.RequestWithBody(e.client, strings.TrimRight(baseURL, "/")+"/favicon.ico", e.userAgent())
+	hasFavicon := favicon.Error == nil && favicon.StatusCode == 200 && !strings.Contains(favicon.ContentType, "html")
+	if hasFavicon {
+		if sig, ok := faviconSignatures[faviconHash(favicon.Body)]; ok {
+			add(sig)
+		}
+	}
+
+	if len(techs) == 0 {
+		utils.PrintInfo("Fingerprint: no technology detected")
+	} else {
+		utils.PrintSuccess("Fingerprint: %s", strings.Join(techs, ", "))
+	}
+
+	if hasFavicon {
+		utils.PrintInfo("Favicon MD5: %s", favicon.BodyHash)
+	}
+
+	if e.config.AutoExtensions {
+		e.addProfileExtensions(profiles)
+	}
+}
+
+// matchHeaders returns the signatures matched by response headers and cookies
+func matchHeaders(header http.Header) []signature {
+	var matched []signature
+	for name, sigs := range headerSignatures {
+		value := strings.ToLower(header.Get(name))
+		if value == "" {
+			continue
+		}
+		for _, sig := range sigs {
+			if strings.Contains(value, sig.match) {
+				matched = append(matched, sig)
+			}
+		}
+	}
+
+	for _, c := range header.Values("Set-Cookie") {
+		name, _, _ := strings.Cut(c, "=")
+		name = strings.TrimSpace(name)
+		for _, sig := range cookieSignatures {
+			if strings.HasPrefix(name, sig.match) {
+				matched = append(matched, sig)
+			}
+		}
+	}
+	return matched
+}
+
+// addProfileExtensions appends the extensions of the detected profiles
+func (e *Engine) addProfileExtensions(profiles map[string]bool) {
+	have := make(map[string]bool)
+	for _, ext := range e.config.Extensions {
+		have[ext] = true
+	}
+
+	var added []string
+	for _, name := range ProfileNames() {
+		if !profiles[name] {
+			continue
+		}
+		for _, ext := range ExtensionProfiles[name] {
+			if !have[ext] {
+				have[ext] = true
+				added = append(added, ext)
+			}
+		}
+	}
+
+	if len(added) > 0 {
+		e.config.Extensions = append(e.config.Extensions, added...)
+		utils.PrintInfo("Fingerprint: added extensions %s", strings.Join(added, ", "))
+	}
+}
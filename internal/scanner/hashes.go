@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadHashes parses a file of MD5 body hashes (hex), one per line, as
+// printed by md5sum: anything after the hash is ignored
+func LoadHashes(filePath string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open hashes file: %w", err)
+	}
+	defer file.Close()
+
+	var hashes []string
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		hash := strings.ToLower(fields[0])
+		if b, err := hex.DecodeString(hash); err != nil || len(b) != 16 {
+			return nil, fmt.Errorf("hashes file line %d: invalid MD5 hash %q", lineNum, fields[0])
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading hashes file: %w", err)
+	}
+	return hashes, nil
+}
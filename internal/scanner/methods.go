@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Fastdev75/xsearch/internal/httpclient"
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// dangerousMethods are methods worth flagging when a server allows them
+var dangerousMethods = map[string]bool{
+	"PUT":       true,
+	"DELETE":    true,
+	"PATCH":     true,
+	"TRACE":     true,
+	"CONNECT":   true,
+	"PROPPATCH": true,
+	"MKCOL":     true,
+	"MOVE":      true,
+	"COPY":      true,
+}
+
+// enumerateMethods sends an OPTIONS request to every finding and reports the
+// advertised methods. Write methods are never actually exercised.
+func (e *Engine) enumerateMethods() {
+	findings := e.getFindings()
+	if len(findings) == 0 {
+		return
+	}
+
+	utils.PrintInfo("Method enumeration: %d findings", len(findings))
+
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < e.config.Threads && i < len(findings); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range jobs {
+				r := httpclient.MethodRequest(e.client, "OPTIONS", url, e.userAgent())
+				if r.Error != nil {
+					continue
+				}
+				methods := allowedMethods(r.Header)
+				if len(methods) == 0 {
+					continue
+				}
+
+				var dangerous []string
+				for _, m := range methods {
+					if dangerousMethods[m] {
+						dangerous = append(dangerous, m)
+					}
+				}
+
+				if len(dangerous) > 0 {
+					utils.PrintWarning("%s -> %s (dangerous: %s)", url, strings.Join(methods, ", "), strings.Join(dangerous, ", "))
+				} else {
+					utils.PrintSuccess("%s -> %s", url, strings.Join(methods, ", "))
+				}
+			}
+		}()
+	}
+
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		url := strings.TrimRight(f.URL, "/")
+		if seen[url] {
+			continue
+		}
+		seen[url] = true
+
+		select {
+		case <-e.ctx.Done():
+			close(jobs)
+			wg.Wait()
+			return
+		case jobs <- f.URL:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// allowedMethods extracts the methods advertised by Allow (or IIS's Public) header
+func allowedMethods(header http.Header) []string {
+	allow := header.Get("Allow")
+	if allow == "" {
+		allow = header.Get("Public")
+	}
+
+	var methods []string
+	for _, m := range strings.Split(allow, ",") {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		if m != "" {
+			methods = append(methods, m)
+		}
+	}
+	return methods
+}
@@ -0,0 +1,95 @@
+package scanner
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+const (
+	// paceRecoverAfter is the number of clean responses before the delay halves
+	paceRecoverAfter = 10
+	// minPaceDelay is the delay below which full speed is restored
+	minPaceDelay = 100 * time.Millisecond
+)
+
+// isBlockStatus reports whether a status code indicates rate limiting or blocking
+func isBlockStatus(statusCode int) bool {
+	return statusCode == 429 || statusCode == 503
+}
+
+// pace applies the adaptive replay delay. While a block is being ridden out,
+// requests are serialized through a single slot and spaced by the current
+// delay; release must be called once the job's requests are done.
+// With -threads-auto a concurrency slot is held as well. -rate and the -hc
+// connection budget apply to each request instead (see gate).
+func (e *Engine) pace() (release func(), ok bool) {
+	releaseSlot, ok := e.acquireSlot()
+	if !ok {
+		return nil, false
+	}
+	if atomic.LoadInt64(&e.paceDelay) == 0 {
+		return releaseSlot, true
+	}
+
+	e.paceMux.Lock()
+	// Re-read: the delay may have dropped while waiting for the slot
+	delay := time.Duration(atomic.LoadInt64(&e.paceDelay))
+	if delay > 0 {
+		select {
+		case <-e.ctx.Done():
+			e.paceMux.Unlock()
+			releaseSlot()
+			return nil, false
+		case <-time.After(delay):
+		}
+	}
+	return func() {
+		e.paceMux.Unlock()
+		releaseSlot()
+	}, true
+}
+
+// recordPace adjusts the replay delay from a response: block statuses switch
+// to the slow pace, and runs of clean responses gradually speed back up
+func (e *Engine) recordPace(statusCode int, err error) {
+	if e.config.ReplayDelay <= 0 || err != nil {
+		return
+	}
+
+	if isBlockStatus(statusCode) {
+		atomic.StoreUint64(&e.cleanStreak, 0)
+		if atomic.SwapInt64(&e.paceDelay, int64(e.config.ReplayDelay)) == 0 {
+			fmt.Println()
+			utils.PrintWarning("Block detected (HTTP %d), slowing to 1 request every %s", statusCode, e.config.ReplayDelay)
+		}
+		return
+	}
+
+	if atomic.LoadInt64(&e.paceDelay) == 0 {
+		return
+	}
+	if atomic.AddUint64(&e.cleanStreak, 1)%paceRecoverAfter != 0 {
+		return
+	}
+
+	for {
+		old := atomic.LoadInt64(&e.paceDelay)
+		if old == 0 {
+			return
+		}
+		next := old / 2
+		if time.Duration(next) < minPaceDelay {
+			next = 0
+		}
+		if atomic.CompareAndSwapInt64(&e.paceDelay, old, next) {
+			if next == 0 {
+				fmt.Println()
+				utils.PrintInfo("Block cleared, resuming full speed")
+			}
+			return
+		}
+	}
+}
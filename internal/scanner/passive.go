@@ -0,0 +1,192 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/httpclient"
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// Sources of the paths discovered without brute-forcing
+const (
+	SourceRobots      = "robots"
+	SourceSitemap     = "sitemap"
+	SourceSecurityTxt = "security.txt"
+	SourceHomepage    = "homepage"
+)
+
+var (
+	// sitemapLoc extracts <loc> entries of sitemaps and sitemap indexes
+	sitemapLoc = regexp.MustCompile(`(?i)<loc>\s*([^<\s]+)\s*</loc>`)
+	// pageLink extracts link, resource and form targets from HTML
+	pageLink = regexp.MustCompile(`(?i)\b(?:href|src|action)\s*=\s*["']([^"']+)["']`)
+)
+
+// runPassive discovers paths from robots.txt, sitemaps, security.txt and the
+// homepage links, then checks each once. No wordlist is used, so the traffic
+// stays a handful of requests plus one per discovered path.
+func (e *Engine) runPassive(baseURL string) {
+	base, err := url.Parse(strings.TrimRight(baseURL, "/") + "/")
+	if err != nil {
+		utils.PrintError("Passive discovery: %s", err)
+		return
+	}
+
+	sources := []string{SourceRobots, SourceSitemap, SourceSecurityTxt, SourceHomepage}
+	found := make(map[string][]string)
+	add := func(source, ref string) {
+		if u := sameHostURL(base, ref); u != "" && !e.isExcludedURL(u) && e.markVisited(u, 0) {
+			found[source] = append(found[source], u)
+		}
+	}
+
+	// robots.txt rules and the sitemaps it declares
+	sitemaps := []string{"/sitemap.xml"}
+	if body, ok := e.fetchMeta(base, "/robots.txt"); ok {
+		add(SourceRobots, "/robots.txt")
+		paths, declared := parseRobots(body)
+		for _, p := range paths {
+			add(SourceRobots, p)
+		}
+		sitemaps = append(sitemaps, declared...)
+	}
+
+	seen := make(map[string]bool)
+	for _, sitemap := range sitemaps {
+		if seen[sitemap] {
+			continue
+		}
+		seen[sitemap] = true
+		body, ok := e.fetchMeta(base, sitemap)
+		if !ok {
+			continue
+		}
+		add(SourceSitemap, sitemap)
+		for _, loc := range parseSitemap(body) {
+			add(SourceSitemap, loc)
+		}
+	}
+
+	for _, p := range []string{"/.well-known/security.txt", "/security.txt"} {
+		if body, ok := e.fetchMeta(base, p); ok {
+			add(SourceSecurityTxt, p)
+			for _, ref := range parseSecurityTxt(body) {
+				add(SourceSecurityTxt, ref)
+			}
+			break
+		}
+	}
+
+	home := httpclient.RequestWithBody(e.client, base.String(), e.userAgent())
+	if home.Error == nil && home.StatusCode == 200 {
+		for _, ref := range parseLinks(home.Body) {
+			add(SourceHomepage, ref)
+		}
+	}
+
+	utils.PrintInfo("Passive: %d paths from robots.txt, %d from sitemaps, %d from security.txt, %d from the homepage",
+		len(found[SourceRobots]), len(found[SourceSitemap]), len(found[SourceSecurityTxt]), len(found[SourceHomepage]))
+	for _, source := range sources {
+		if len(found[source]) > 0 {
+			e.probeDirectories(found[source], 0, source)
+		}
+	}
+}
+
+// fetchMeta fetches a metadata file, returning its body if it exists
+func (e *Engine) fetchMeta(base *url.URL, ref string) ([]byte, bool) {
+	u := sameHostURL(base, ref)
+	if u == "" {
+		return nil, false
+	}
+	r := httpclient.RequestWithBody(e.client, u, e.userAgent())
+	if r.Error != nil || r.StatusCode != 200 || e.isSoft404(r.BodyHash, r.Size) {
+		return nil, false
+	}
+	return r.Body, true
+}
+
+// sameHostURL resolves ref against base and returns it without query and
+// fragment, or "" if it points to another host or is not HTTP(S)
+func sameHostURL(base *url.URL, ref string) string {
+	u, err := base.Parse(strings.TrimSpace(ref))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || !strings.EqualFold(u.Host, base.Host) {
+		return ""
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	if u.Path == "" || u.Path == "/" {
+		return ""
+	}
+	return u.String()
+}
+
+// parseRobots returns the Allow/Disallow paths of a robots.txt, cut before
+// any wildcard, and the declared sitemaps
+func parseRobots(body []byte) (paths, sitemaps []string) {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "allow", "disallow":
+			if i := strings.IndexAny(value, "*$"); i != -1 {
+				value = value[:i]
+			}
+			if value != "" && value != "/" {
+				paths = append(paths, value)
+			}
+		case "sitemap":
+			if value != "" {
+				sitemaps = append(sitemaps, value)
+			}
+		}
+	}
+	return paths, sitemaps
+}
+
+// parseSitemap returns the <loc> URLs of a sitemap or sitemap index
+func parseSitemap(body []byte) []string {
+	var locs []string
+	for _, m := range sitemapLoc.FindAllSubmatch(body, -1) {
+		locs = append(locs, html.UnescapeString(string(m[1])))
+	}
+	return locs
+}
+
+// parseSecurityTxt returns the URLs of a security.txt (Policy, Hiring, ...)
+func parseSecurityTxt(body []byte) []string {
+	var refs []string
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		_, value, ok := strings.Cut(scanner.Text(), ":")
+		value = strings.TrimSpace(value)
+		if ok && (strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://")) {
+			refs = append(refs, value)
+		}
+	}
+	return refs
+}
+
+// parseLinks returns the href, src and action targets of an HTML page
+func parseLinks(body []byte) []string {
+	var refs []string
+	for _, m := range pageLink.FindAllSubmatch(body, -1) {
+		ref := html.UnescapeString(string(m[1]))
+		if lower := strings.ToLower(ref); strings.HasPrefix(lower, "javascript:") ||
+			strings.HasPrefix(lower, "mailto:") || strings.HasPrefix(lower, "data:") {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
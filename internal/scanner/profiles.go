@@ -0,0 +1,56 @@
+package scanner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExtensionProfiles are named extension bundles selectable with -x-profile
+var ExtensionProfiles = map[string][]string{
+	"web":     {"php", "asp", "aspx", "jsp", "html", "htm"},
+	"php":     {"php", "php3", "php4", "php5", "phtml", "inc"},
+	"dotnet":  {"asp", "aspx", "ashx", "asmx", "axd", "config"},
+	"java":    {"jsp", "jspx", "do", "action", "jar", "war"},
+	"js":      {"js", "mjs", "map", "json"},
+	"backup":  {"bak", "backup", "old", "orig", "save", "swp", "tmp", "copy"},
+	"config":  {"conf", "config", "cfg", "ini", "env", "yml", "yaml", "toml", "properties", "xml"},
+	"archive": {"zip", "tar", "gz", "tar.gz", "tgz", "rar", "7z", "bz2"},
+	"data":    {"sql", "db", "sqlite", "mdb", "csv", "json", "log"},
+}
+
+// ExpandProfiles resolves a comma-separated list of profile names into a
+// deduplicated extension list
+func ExpandProfiles(names string) ([]string, error) {
+	var exts []string
+	seen := make(map[string]bool)
+
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		profile, ok := ExtensionProfiles[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown extension profile: %s (available: %s)", name, strings.Join(ProfileNames(), ", "))
+		}
+		for _, ext := range profile {
+			if !seen[ext] {
+				seen[ext] = true
+				exts = append(exts, ext)
+			}
+		}
+	}
+
+	return exts, nil
+}
+
+// ProfileNames returns the sorted names of all extension profiles
+func ProfileNames() []string {
+	names := make([]string, 0, len(ExtensionProfiles))
+	for name := range ExtensionProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
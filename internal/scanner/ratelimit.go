@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces requests evenly to a maximum rate shared by all workers
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time // Earliest time the next request may start
+}
+
+func newRateLimiter(perSecond int) *rateLimiter {
+	return &rateLimiter{interval: time.Second / time.Duration(perSecond)}
+}
+
+// Wait blocks until the caller may send a request, or ctx is done
+func (l *rateLimiter) Wait(ctx context.Context) bool {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	at := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// gate runs before every request the client puts on the wire (retries,
+// calibration, confirmation and redirect hops included): it applies -rate,
+// then holds a unit of the -hc connection budget until the body is closed
+func (e *Engine) gate(req *http.Request) (release func(), err error) {
+	if !e.waitRate() {
+		return nil, e.ctx.Err()
+	}
+	release, ok := e.acquireConn()
+	if !ok {
+		return nil, e.ctx.Err()
+	}
+	return release, nil
+}
+
+// waitRate applies -rate before a request. It returns false once the scan
+// is stopped.
+func (e *Engine) waitRate() bool {
+	if e.limiter == nil {
+		return true
+	}
+	return e.limiter.Wait(e.ctx)
+}
@@ -0,0 +1,12 @@
+package scanner
+
+import "github.com/Fastdev75/xsearch/internal/httpclient"
+
+// withRetry sends a request, retrying transient failures up to -retries times.
+// Only the final outcome reaches the error counters and the circuit breaker.
+func (e *Engine) withRetry(do func() *httpclient.Result) *httpclient.Result {
+	if e.config.Retries <= 0 {
+		return do()
+	}
+	return httpclient.Retry(e.ctx, e.config.Retries, do)
+}
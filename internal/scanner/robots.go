@@ -0,0 +1,40 @@
+package scanner
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// seedRobots probes the Allow/Disallow paths of robots.txt before Phase 1;
+// the directories among them are then scanned like wordlist finds. A missing
+// robots.txt only skips the stage.
+func (e *Engine) seedRobots(baseURL string) {
+	base, err := url.Parse(strings.TrimRight(baseURL, "/") + "/")
+	if err != nil {
+		utils.PrintError("Robots: %s", err)
+		return
+	}
+
+	body, ok := e.fetchMeta(base, "/robots.txt")
+	if !ok {
+		utils.PrintInfo("Robots: no robots.txt, skipping")
+		return
+	}
+
+	paths, _ := parseRobots(body)
+	var urls []string
+	for _, p := range paths {
+		if u := sameHostURL(base, p); u != "" && !e.isExcludedURL(u) && e.markVisited(u, 0) {
+			urls = append(urls, u)
+		}
+	}
+	if len(urls) == 0 {
+		utils.PrintInfo("Robots: no paths listed")
+		return
+	}
+
+	utils.PrintInfo("Robots: probing %d paths from robots.txt", len(urls))
+	e.probeDirectories(urls, 0, SourceRobots)
+}
@@ -0,0 +1,109 @@
+package scanner
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Rule tags findings whose path matches a glob and, optionally, a status code.
+// Rules file syntax, one rule per line:
+//
+//	*.sql => database-backup
+//	admin* 200,403 => admin-panel
+//	/api/* => api
+//
+// Patterns without a slash match the last path segment, others the full path.
+type Rule struct {
+	Pattern string
+	Codes   map[int]bool // Empty = any status
+	Tag     string
+}
+
+// LoadRules parses a rules file
+func LoadRules(filePath string) ([]Rule, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rules file: %w", err)
+	}
+	defer file.Close()
+
+	var rules []Rule
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		left, tag, ok := strings.Cut(line, "=>")
+		tag = strings.TrimSpace(tag)
+		fields := strings.Fields(left)
+		if !ok || tag == "" || len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("rules file line %d: expected \"<pattern> [codes] => <tag>\"", lineNum)
+		}
+
+		rule := Rule{Pattern: fields[0], Codes: make(map[int]bool), Tag: tag}
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("rules file line %d: invalid pattern %q", lineNum, rule.Pattern)
+		}
+		if len(fields) == 2 {
+			for _, c := range strings.Split(fields[1], ",") {
+				code, err := strconv.Atoi(strings.TrimSpace(c))
+				if err != nil {
+					return nil, fmt.Errorf("rules file line %d: invalid status code %q", lineNum, c)
+				}
+				rule.Codes[code] = true
+			}
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rules file: %w", err)
+	}
+	return rules, nil
+}
+
+// Match reports whether the rule applies to a URL path and status code
+func (r *Rule) Match(urlPath string, statusCode int) bool {
+	if len(r.Codes) > 0 && !r.Codes[statusCode] {
+		return false
+	}
+
+	target := strings.TrimRight(urlPath, "/")
+	if !strings.Contains(r.Pattern, "/") {
+		target = path.Base(target)
+	}
+	matched, _ := path.Match(r.Pattern, target)
+	return matched
+}
+
+// tagsFor returns the tags of every rule matching a finding
+func (e *Engine) tagsFor(rawURL string, statusCode int) []string {
+	if len(e.config.Rules) == 0 {
+		return nil
+	}
+
+	urlPath := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		urlPath = u.Path
+	}
+
+	var tags []string
+	seen := make(map[string]bool)
+	for i := range e.config.Rules {
+		rule := &e.config.Rules[i]
+		if !seen[rule.Tag] && rule.Match(urlPath, statusCode) {
+			seen[rule.Tag] = true
+			tags = append(tags, rule.Tag)
+		}
+	}
+	return tags
+}
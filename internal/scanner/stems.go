@@ -0,0 +1,113 @@
+package scanner
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// SourceStemExpand marks findings from the -stem-expand stage
+const SourceStemExpand = "stem-expand"
+
+// expandStems probes every file found with a 2xx status under the other
+// extensions of its extension profiles: backup.zip also tries backup.tar.gz,
+// backup.rar, backup.7z, ... Related artifacts of the same data are found
+// even when the wordlist only hit one of them.
+func (e *Engine) expandStems() {
+	var urls []string
+	for _, f := range e.getFindings() {
+		if f.IsDir || f.StatusCode < 200 || f.StatusCode >= 300 {
+			continue
+		}
+		stem, ext := splitKnownExtension(f.URL)
+		if ext == "" {
+			continue
+		}
+		for _, related := range relatedExtensions(ext) {
+			fullURL := stem + "." + related
+			if !e.isExcludedURL(fullURL) && e.markVisited(fullURL, 0) {
+				urls = append(urls, fullURL)
+			}
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	utils.PrintInfo("Stem expansion: %d related files", len(urls))
+	e.probeFiles(urls, SourceStemExpand)
+}
+
+// splitKnownExtension splits url into stem and the longest profile extension
+// it ends with (backup.tar.gz -> backup, tar.gz). ext is empty if none matches.
+func splitKnownExtension(url string) (stem, ext string) {
+	name := url[strings.LastIndex(url, "/")+1:]
+	for _, profile := range ExtensionProfiles {
+		for _, candidate := range profile {
+			if len(candidate) > len(ext) && strings.HasSuffix(strings.ToLower(name), "."+candidate) && len(name) > len(candidate)+1 {
+				ext = candidate
+			}
+		}
+	}
+	if ext == "" {
+		return url, ""
+	}
+	return url[:len(url)-len(ext)-1], ext
+}
+
+// relatedExtensions returns the other extensions of every profile containing
+// ext, sorted
+func relatedExtensions(ext string) []string {
+	seen := map[string]bool{ext: true}
+	var related []string
+	for _, profile := range ExtensionProfiles {
+		if !containsExt(profile, ext) {
+			continue
+		}
+		for _, candidate := range profile {
+			if !seen[candidate] {
+				seen[candidate] = true
+				related = append(related, candidate)
+			}
+		}
+	}
+	sort.Strings(related)
+	return related
+}
+
+func containsExt(exts []string, ext string) bool {
+	for _, e := range exts {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// probeFiles runs a fixed list of file candidates through the file phase
+// workers and result handling
+func (e *Engine) probeFiles(urls []string, source string) {
+	jobs := make(chan Job, len(urls))
+	results := make(chan Result, e.config.Threads*4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < e.config.Threads && i < len(urls); i++ {
+		wg.Add(1)
+		go e.workerFiles(jobs, results, &wg)
+	}
+
+	var resultWg sync.WaitGroup
+	resultWg.Add(1)
+	go e.handleFileResults(results, &resultWg)
+
+	for _, u := range urls {
+		jobs <- Job{URL: u, Source: source}
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(results)
+	resultWg.Wait()
+}
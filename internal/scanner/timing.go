@@ -0,0 +1,48 @@
+package scanner
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// timingReservoirSize bounds the memory of -timing-stats: beyond it, samples
+// are kept with reservoir sampling so percentiles stay representative
+const timingReservoirSize = 10000
+
+// recordTiming samples a response time for the -timing-stats percentiles
+func (e *Engine) recordTiming(d time.Duration) {
+	if !e.config.TimingStats {
+		return
+	}
+
+	e.timingsMux.Lock()
+	defer e.timingsMux.Unlock()
+
+	e.timingsSeen++
+	if len(e.timings) < timingReservoirSize {
+		e.timings = append(e.timings, d)
+	} else if i := rand.Int63n(e.timingsSeen); i < timingReservoirSize {
+		e.timings[i] = d
+	}
+}
+
+// printTimingStats prints the response time percentiles of answered requests
+func (e *Engine) printTimingStats() {
+	e.timingsMux.Lock()
+	samples := make([]time.Duration, len(e.timings))
+	copy(samples, e.timings)
+	e.timingsMux.Unlock()
+	if len(samples) == 0 {
+		return
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	percentile := func(p float64) time.Duration {
+		return samples[int(p*float64(len(samples)-1))].Round(time.Microsecond * 100)
+	}
+	utils.PrintInfo("Latency: p50 %s | p90 %s | p99 %s | max %s",
+		percentile(0.50), percentile(0.90), percentile(0.99), samples[len(samples)-1].Round(time.Microsecond*100))
+}
@@ -0,0 +1,230 @@
+package scanner
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// unifiedScan is the shared queue of a -unified scan. pending counts the jobs
+// queued but not yet handled plus the running URL generators; the queue is
+// closed when it drops to zero.
+type unifiedScan struct {
+	jobs    chan Job
+	pending int64
+
+	// Owned by the result handler
+	queued   map[string]bool // Directories already scheduled
+	recursed map[string]bool // Directories whose recursion was decided
+	dirs     int             // Directories scheduled, for -max-dirs
+	limited  bool            // -max-dirs warning printed
+}
+
+func (u *unifiedScan) add() {
+	atomic.AddInt64(&u.pending, 1)
+}
+
+func (u *unifiedScan) done() {
+	if atomic.AddInt64(&u.pending, -1) == 0 {
+		close(u.jobs)
+	}
+}
+
+// scanUnified discovers directories and files in a single pass: as soon as a
+// directory is found, its subdirectory and file candidates join the queue of
+// one shared worker pool. File findings arrive early and no phase waits for
+// the slowest directory of the previous one.
+func (e *Engine) scanUnified(baseURL string) {
+	u := &unifiedScan{
+		jobs:     make(chan Job, e.config.Threads*4),
+		queued:   map[string]bool{baseURL: true},
+		recursed: map[string]bool{baseURL: true},
+	}
+	results := make(chan Result, e.config.Threads*4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < e.config.Threads; i++ {
+		wg.Add(1)
+		go e.workerUnified(u.jobs, results, &wg)
+	}
+
+	atomic.StoreUint64(&e.total, 0)
+	progressDone := e.startProgress(0)
+
+	// The root generators hold the queue open until the first jobs exist
+	e.queueDirectoryScan(u, baseURL, 0)
+	if len(e.config.Extensions) > 0 {
+		e.queueFileScan(u, baseURL)
+	}
+
+	// Directories stored before the scan (-robots) are scheduled like finds.
+	// This runs before the result handler starts, which owns u.queued.
+	for _, dir := range e.getDirectoriesAtDepth(0) {
+		e.scheduleDirectory(u, dir, 0)
+	}
+
+	var resultWg sync.WaitGroup
+	resultWg.Add(1)
+	go e.handleUnifiedResults(u, results, &resultWg)
+
+	wg.Wait()
+	close(results)
+	resultWg.Wait()
+	close(progressDone)
+}
+
+// workerUnified probes directory and file candidates from the shared queue
+func (e *Engine) workerUnified(jobs <-chan Job, results chan<- Result, wg *sync.WaitGroup) {
+	defer wg.Done()
+	delay := e.workerDelay()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case job, ok := <-jobs:
+			if !ok {
+				return
+			}
+			probe := e.probeDirectoryJob
+			if job.File {
+				probe = e.probeFileJob
+			}
+			result, ok := probe(job)
+			if !ok {
+				return
+			}
+
+			select {
+			case <-e.ctx.Done():
+				return
+			case results <- result:
+			}
+			if !delay() {
+				return
+			}
+		}
+	}
+}
+
+// handleUnifiedResults reports the results of the shared queue and schedules
+// the directories they reveal
+func (e *Engine) handleUnifiedResults(u *unifiedScan, results <-chan Result, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for r := range results {
+		if r.File {
+			e.handleFileResult(r)
+		} else if dir := e.handleDirectoryResult(r, r.Depth); dir != "" {
+			e.scheduleDirectory(u, dir, r.Depth)
+		}
+		// Scheduling above happens first, so pending cannot reach zero early
+		u.done()
+	}
+}
+
+// scheduleDirectory queues the work for a directory found at depth: its API
+// versions, its files and, when it may be recursed into, its subdirectories.
+// The same checks as the phase boundaries apply. A directory found again
+// (dir and dir/) is only reconsidered for -rc recursion.
+func (e *Engine) scheduleDirectory(u *unifiedScan, dir string, depth int) {
+	if !u.queued[dir] {
+		u.queued[dir] = true
+
+		if e.config.APIExpand {
+			if urls := e.apiVersionURLs(dir, depth); len(urls) > 0 {
+				e.queueURLs(u, urls, depth+1, SourceAPIExpand)
+			}
+		}
+
+		if e.config.MaxDirs > 0 && u.dirs >= e.config.MaxDirs {
+			if !u.limited {
+				u.limited = true
+				utils.PrintWarning("Directory limit reached (%d): skipping further directories, coverage is truncated", e.config.MaxDirs)
+			}
+			u.recursed[dir] = true
+			return
+		}
+		u.dirs++
+
+		if e.isAssetDirectory(dir) {
+			u.recursed[dir] = true
+			return
+		}
+		if len(e.config.Extensions) > 0 {
+			e.queueFileScan(u, dir)
+		}
+	}
+
+	if u.recursed[dir] || !e.isRecurseDir(dir) {
+		return
+	}
+	u.recursed[dir] = true
+	if e.config.Recursive && depth+1 <= e.maxDepthFor(dir) && e.parentHasMinFindings(dir) && e.isRecurseTarget(dir) &&
+		!e.budgetSpent() {
+		e.queueDirectoryScan(u, dir, depth+1)
+	}
+}
+
+// queueDirectoryScan starts queueing the directory candidates under basePath
+func (e *Engine) queueDirectoryScan(u *unifiedScan, basePath string, depth int) {
+	words, seen := e.wordSource()
+	atomic.AddUint64(&e.total, e.estimateURLs(e.wordCount(), e.dirURLsPerWord()))
+	urls := e.buildDirectoryURLs(words, basePath, depth)
+
+	e.queueGenerated(u, urls, depth, seen, e.dirURLsPerWord(), false, func(added <-chan string) <-chan string {
+		return e.buildDirectoryURLs(added, basePath, depth)
+	})
+}
+
+// queueFileScan starts queueing the file candidates in basePath
+func (e *Engine) queueFileScan(u *unifiedScan, basePath string) {
+	words, seen := e.wordSource()
+	atomic.AddUint64(&e.total, e.estimateURLs(e.wordCount(), e.fileURLsPerWord()))
+	urls := e.buildFileURLs(words, basePath)
+
+	e.queueGenerated(u, urls, 0, seen, e.fileURLsPerWord(), true, func(added <-chan string) <-chan string {
+		return e.buildFileURLs(added, basePath)
+	})
+}
+
+// queueGenerated feeds generated URLs into the shared queue from a goroutine,
+// so the result handler never blocks on a full queue
+func (e *Engine) queueGenerated(u *unifiedScan, urls <-chan string, depth int, seen int, perWord int, file bool, build func(added <-chan string) <-chan string) {
+	u.add()
+	go func() {
+		defer u.done()
+		e.feedURLs(func(job Job) bool {
+			job.File = file
+			return e.queueJob(u, job)
+		}, urls, depth, seen, perWord, build)
+	}()
+}
+
+// queueURLs feeds a fixed list of directory candidates into the shared queue
+func (e *Engine) queueURLs(u *unifiedScan, urls []string, depth int, source string) {
+	atomic.AddUint64(&e.total, uint64(len(urls)))
+	u.add()
+	go func() {
+		defer u.done()
+		for _, url := range urls {
+			if !e.queueJob(u, Job{URL: url, Depth: depth, Source: source}) {
+				return
+			}
+		}
+	}()
+}
+
+// queueJob counts a job as pending and queues it, reporting false once the
+// scan is stopped
+func (e *Engine) queueJob(u *unifiedScan, job Job) bool {
+	u.add()
+	select {
+	case <-e.ctx.Done():
+		u.done()
+		return false
+	case u.jobs <- job:
+		return true
+	}
+}
@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"path"
+	"strings"
+
+	"github.com/Fastdev75/xsearch/internal/utils"
+)
+
+// SourceWellKnown marks findings from the -well-known stage
+const SourceWellKnown = "well-known"
+
+// wellKnownPaths are standardized /.well-known/ resources (RFC 8615) carrying
+// metadata generic wordlists rarely cover
+var wellKnownPaths = []string{
+	"security.txt",
+	"openid-configuration",
+	"oauth-authorization-server",
+	"jwks.json",
+	"apple-app-site-association",
+	"assetlinks.json",
+	"change-password",
+	"host-meta",
+	"host-meta.json",
+	"webfinger",
+	"nodeinfo",
+	"mta-sts.txt",
+	"gpc.json",
+}
+
+// probeWellKnown checks the /.well-known/ resources of the base URL and
+// lists the ones found. They are documents, so they are probed as files and
+// never recursed into.
+func (e *Engine) probeWellKnown(baseURL string) {
+	var urls []string
+	for _, name := range wellKnownPaths {
+		fullURL := strings.TrimRight(baseURL, "/") + "/.well-known/" + name
+		if !e.isExcludedURL(fullURL) && e.markVisited(fullURL, 0) {
+			urls = append(urls, fullURL)
+		}
+	}
+	if len(urls) == 0 {
+		return
+	}
+
+	utils.PrintInfo("Well-known: probing %d resources", len(urls))
+	e.probeFiles(urls, SourceWellKnown)
+
+	var found []string
+	for _, f := range e.getFindings() {
+		if f.Source == SourceWellKnown && f.StatusCode >= 200 && f.StatusCode < 300 {
+			found = append(found, path.Base(f.URL))
+		}
+	}
+	if len(found) > 0 {
+		utils.PrintSuccess("Well-known: %s", strings.Join(found, ", "))
+	}
+}
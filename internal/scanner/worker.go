@@ -1,17 +1,104 @@
 package scanner
 
+import (
+	"time"
+
+	"github.com/Fastdev75/xsearch/internal/httpclient"
+)
+
+// Discovery sources reported per finding
+const (
+	SourceWordlist = "wordlist"
+	SourceRoot     = "root"
+)
+
 // Job represents a scanning job
 type Job struct {
-	URL   string
-	Depth int
+	URL    string
+	Word   string // Raw word substituted into request templates
+	Depth  int
+	Source string
+	File   bool // File candidate, for the shared queue of -unified
 }
 
 // Result represents a scan result
 type Result struct {
-	URL        string
-	StatusCode int
-	Size       int64
-	BodyHash   string
-	Depth      int
-	Error      error
+	URL          string
+	StatusCode   int
+	Size         int64
+	BodyHash     string
+	Words        int    // Body word count, valid when BodyHash is set
+	Lines        int    // Body line count, valid when BodyHash is set
+	Body         []byte // Body read by the GET, if any
+	ContentType  string
+	RedirectURL  string
+	Loop         bool     // Self-redirect or redirect loop, never a directory
+	Chain        []string // Followed redirects (-follow), request first
+	Tags         []string // Labels from matching -rules entries
+	Depth        int
+	Source       string
+	File         bool
+	Duration     time.Duration
+	HeadSize     int64 // Content-Length announced by HEAD
+	SizeDiffers  bool  // HEAD Content-Length disagrees with the verification GET body
+	BustArtifact bool  // 200 only with the cache buster, 404 without it
+	Error        error
+}
+
+// newResult builds a scan result from the primary response, overlaying the
+// body-derived fields of the verification GET when one succeeded. Each job
+// yields exactly one Result, so the verification GET is never counted twice.
+func newResult(job Job, primary, verify *httpclient.Result) Result {
+	r := Result{
+		URL:         job.URL,
+		StatusCode:  primary.StatusCode,
+		Size:        primary.Size,
+		BodyHash:    primary.BodyHash,
+		Words:       primary.Words,
+		Lines:       primary.Lines,
+		Body:        primary.Body,
+		ContentType: primary.ContentType,
+		RedirectURL: primary.RedirectURL,
+		Loop:        primary.RedirectLoop,
+		Chain:       primary.RedirectChain,
+		Depth:       job.Depth,
+		Source:      job.Source,
+		File:        job.File,
+		Duration:    primary.Duration,
+		HeadSize:    primary.Size,
+		Error:       primary.Error,
+	}
+	if verify != nil && verify.Error == nil {
+		r.SizeDiffers = verify != primary && sizeMismatch(primary.Size, verify.Size)
+		r.Size = verify.Size
+		r.BodyHash = verify.BodyHash
+		r.Words = verify.Words
+		r.Lines = verify.Lines
+		r.Body = verify.Body
+		if verify.ContentType != "" {
+			r.ContentType = verify.ContentType
+		}
+	}
+	return r
+}
+
+// Verification GETs read at most this much of the body (see httpclient.Send)
+const maxVerifiedBody = 512 * 1024
+
+// sizeMismatch reports whether the HEAD Content-Length differs significantly
+// (over 10% and 64 bytes) from the body size read by the verification GET.
+// An unknown HEAD length and bodies truncated by the read limit never count.
+func sizeMismatch(headSize, getSize int64) bool {
+	if headSize < 0 || getSize >= maxVerifiedBody {
+		return false
+	}
+	diff := headSize - getSize
+	if diff < 0 {
+		diff = -diff
+	}
+	larger := headSize
+	if getSize > larger {
+		larger = getSize
+	}
+	return diff > 64 && float64(diff) > float64(larger)*0.1
 }
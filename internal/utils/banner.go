@@ -2,8 +2,8 @@ package utils
 
 import "fmt"
 
-// ANSI color codes
-const (
+// ANSI color codes (cleared when color is disabled, see SetColorEnabled)
+var (
 	Red    = "\033[31m"
 	Green  = "\033[32m"
 	Yellow = "\033[33m"
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+)
+
+// Color modes accepted by -color
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// ansiCodes keeps the original escape sequences so color can be re-enabled
+var ansiCodes = map[*string]string{
+	&Red: Red, &Green: Green, &Yellow: Yellow, &Blue: Blue,
+	&Cyan: Cyan, &White: White, &Reset: Reset, &Bold: Bold,
+}
+
+// SetColorEnabled turns ANSI color output on or off globally
+func SetColorEnabled(enabled bool) {
+	for ptr, code := range ansiCodes {
+		if enabled {
+			*ptr = code
+		} else {
+			*ptr = ""
+		}
+	}
+}
+
+// IsTerminal reports whether the file is attached to a terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ApplyColorMode configures color output from an auto/always/never mode
+func ApplyColorMode(mode string) error {
+	switch mode {
+	case ColorAuto, "":
+		SetColorEnabled(IsTerminal(os.Stdout))
+	case ColorAlways:
+		SetColorEnabled(true)
+	case ColorNever:
+		SetColorEnabled(false)
+	default:
+		return fmt.Errorf("invalid color mode: %s (use auto, always or never)", mode)
+	}
+	return nil
+}
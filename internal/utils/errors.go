@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ErrorCode categorizes fatal errors so automation can react to them
+type ErrorCode string
+
+// Fatal error categories
+const (
+	ErrConfig   ErrorCode = "config"   // Invalid flags or input files
+	ErrWordlist ErrorCode = "wordlist" // Wordlist missing or unreadable
+	ErrTarget   ErrorCode = "target"   // Target unreachable or down
+	ErrOutput   ErrorCode = "output"   // Output file or sink unavailable
+	ErrInternal ErrorCode = "internal" // Anything else
+)
+
+// exitCodes are the process exit codes used with -json-errors
+var exitCodes = map[ErrorCode]int{
+	ErrInternal: 1,
+	ErrConfig:   2,
+	ErrWordlist: 3,
+	ErrTarget:   4,
+	ErrOutput:   5,
+}
+
+var jsonErrors bool
+
+// SetJSONErrors switches Fatal to machine-readable output on stderr
+func SetJSONErrors(enabled bool) {
+	jsonErrors = enabled
+}
+
+// Fatal reports an error and exits. By default it prints like PrintError and
+// exits with 1; in JSON mode it writes {"error":"...","code":"..."} to stderr
+// and exits with the category's exit code.
+func Fatal(code ErrorCode, format string, args ...interface{}) {
+	os.Exit(Report(code, format, args...))
+}
+
+// Report prints an error like Fatal and returns the exit code Fatal would
+// use, for callers that must close their outputs before exiting
+func Report(code ErrorCode, format string, args ...interface{}) int {
+	msg := fmt.Sprintf(format, args...)
+	if !jsonErrors {
+		PrintError("%s", msg)
+		return 1
+	}
+
+	data, _ := json.Marshal(struct {
+		Error string    `json:"error"`
+		Code  ErrorCode `json:"code"`
+	}{msg, code})
+	fmt.Fprintln(os.Stderr, string(data))
+
+	exit, ok := exitCodes[code]
+	if !ok {
+		exit = 1
+	}
+	return exit
+}
@@ -2,6 +2,9 @@ package wordlist
 
 import (
 	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,10 +26,13 @@ var DefaultWordlists = []string{
 // Bundled wordlist URL (SecLists common.txt)
 const BundledWordlistURL = "https://raw.githubusercontent.com/danielmiessler/SecLists/master/Discovery/Web-Content/common.txt"
 
-// Manager handles wordlist operations
+// Manager handles wordlist operations. Several files are read in order as
+// one list.
 type Manager struct {
-	path  string
-	words []string
+	paths      []string
+	words      []string
+	duplicates int             // Entries dropped by Load as already seen
+	seen       map[string]bool // Entries already handed out, for incremental reloads
 }
 
 // getXsearchDir returns the xsearch data directory
@@ -38,18 +44,23 @@ func getXsearchDir() string {
 	return filepath.Join(home, ".xsearch")
 }
 
-// NewManager creates a new wordlist manager
+// NewManager creates a new wordlist manager. customPath may list several
+// comma-separated files, merged in order.
 func NewManager(customPath string) (*Manager, error) {
 	m := &Manager{}
 
-	if customPath != "" {
-		m.path = customPath
-	} else {
+	for _, p := range strings.Split(customPath, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			m.paths = append(m.paths, p)
+		}
+	}
+
+	if len(m.paths) == 0 {
 		// Find first available default wordlist
 		found := false
 		for _, wl := range DefaultWordlists {
 			if _, err := os.Stat(wl); err == nil {
-				m.path = wl
+				m.paths = []string{wl}
 				found = true
 				break
 			}
@@ -59,7 +70,7 @@ func NewManager(customPath string) (*Manager, error) {
 		if !found {
 			bundledPath := filepath.Join(getXsearchDir(), "wordlists", "common.txt")
 			if _, err := os.Stat(bundledPath); err == nil {
-				m.path = bundledPath
+				m.paths = []string{bundledPath}
 				found = true
 			}
 		}
@@ -71,14 +82,16 @@ func NewManager(customPath string) (*Manager, error) {
 			if err != nil {
 				return nil, fmt.Errorf("failed to download wordlist: %w\nInstall manually: sudo apt install seclists", err)
 			}
-			m.path = downloadedPath
+			m.paths = []string{downloadedPath}
 			utils.PrintSuccess("Wordlist downloaded to: %s", downloadedPath)
 		}
 	}
 
-	// Verify wordlist file exists
-	if _, err := os.Stat(m.path); os.IsNotExist(err) {
-		return nil, fmt.Errorf("wordlist not found: %s", m.path)
+	// Verify wordlist files exist
+	for _, p := range m.paths {
+		if _, err := os.Stat(p); os.IsNotExist(err) {
+			return nil, fmt.Errorf("wordlist not found: %s", p)
+		}
 	}
 
 	return m, nil
@@ -122,15 +135,86 @@ func downloadWordlist() (string, error) {
 	return destPath, nil
 }
 
-// Load reads the wordlist file and returns words
+// Load reads the wordlist files and returns their words, duplicates removed
 func (m *Manager) Load() ([]string, error) {
-	file, err := os.Open(m.path)
+	all, err := m.read()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open wordlist: %w", err)
+		return nil, err
 	}
-	defer file.Close()
 
+	words := make([]string, 0, len(all))
+	m.seen = make(map[string]bool, len(all))
+	for _, w := range all {
+		if !m.seen[w] {
+			m.seen[w] = true
+			words = append(words, w)
+		}
+	}
+	m.words = words
+	m.duplicates = len(all) - len(words)
+
+	if len(m.paths) == 1 {
+		utils.PrintInfo("Wordlist: %s (%d entries)", m.paths[0], len(words))
+	} else {
+		utils.PrintInfo("Wordlists: %s (%d entries merged, %d duplicates removed)", m.GetPath(), len(words), m.duplicates)
+	}
+
+	return words, nil
+}
+
+// Reload re-reads the wordlist files and returns only entries not seen before
+func (m *Manager) Reload() ([]string, error) {
+	words, err := m.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var added []string
+	for _, w := range words {
+		if !m.seen[w] {
+			m.seen[w] = true
+			added = append(added, w)
+		}
+	}
+	m.words = append(m.words, added...)
+
+	return added, nil
+}
+
+// read parses the wordlist files, skipping blank lines and comments
+func (m *Manager) read() ([]string, error) {
 	var words []string
+	err := m.each(func(word string) bool {
+		words = append(words, word)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// each calls fn for every entry of the wordlist files, skipping blank lines
+// and comments, until fn returns false
+func (m *Manager) each(fn func(word string) bool) error {
+	for _, p := range m.paths {
+		more, err := eachInFile(p, fn)
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+// eachInFile calls fn for every entry of one wordlist file. It reports
+// whether fn wants more entries.
+func eachInFile(path string, fn func(word string) bool) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open wordlist: %w", err)
+	}
+	defer file.Close()
+
 	scanner := bufio.NewScanner(file)
 
 	// Increase buffer size for large lines
@@ -141,23 +225,124 @@ func (m *Manager) Load() ([]string, error) {
 	for scanner.Scan() {
 		word := strings.TrimSpace(scanner.Text())
 		if word != "" && !strings.HasPrefix(word, "#") {
-			words = append(words, word)
+			if !fn(word) {
+				return false, nil
+			}
 		}
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading wordlist: %w", err)
+		return false, fmt.Errorf("error reading wordlist: %w", err)
 	}
 
-	m.words = words
-	utils.PrintInfo("Wordlist: %s (%d entries)", m.path, len(words))
+	return true, nil
+}
 
-	return words, nil
+// Open prepares the wordlist for streaming: entries are counted but not
+// kept in memory. Use Words to iterate them.
+func (m *Manager) Open() (int, error) {
+	count := 0
+	err := m.each(func(string) bool {
+		count++
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	utils.PrintInfo("Wordlist: %s (%d entries, streamed)", m.GetPath(), count)
+	return count, nil
+}
+
+// Words streams the wordlist files entry by entry without loading them into
+// memory. The files are re-read on every call; the channel is closed at the
+// end of the last file or when ctx is done.
+func (m *Manager) Words(ctx context.Context) <-chan string {
+	words := make(chan string, 1024)
+	go func() {
+		defer close(words)
+		err := m.each(func(word string) bool {
+			select {
+			case <-ctx.Done():
+				return false
+			case words <- word:
+				return true
+			}
+		})
+		if err != nil {
+			utils.PrintError("%s", err)
+		}
+	}()
+	return words
+}
+
+// Stats summarizes the loaded wordlist
+type Stats struct {
+	Total      int
+	Unique     int
+	Duplicates int
+	Dotted     int // Entries with a dot past the first character, skipped by the directory phase
+	AvgLength  float64
+}
+
+// Stats computes statistics about the loaded words. Total counts the
+// duplicates removed by Load.
+func (m *Manager) Stats() Stats {
+	st := Stats{Total: len(m.words) + m.duplicates, Unique: len(m.words), Duplicates: m.duplicates}
+	if st.Unique == 0 {
+		return st
+	}
+
+	totalLen := 0
+	for _, w := range m.words {
+		totalLen += len(w)
+		// Dotfiles like .git or .env are still probed as directories
+		if strings.Contains(strings.TrimPrefix(w, "."), ".") {
+			st.Dotted++
+		}
+	}
+	st.AvgLength = float64(totalLen) / float64(st.Unique)
+
+	return st
+}
+
+// PrintStats prints a preflight summary of the loaded wordlist
+func (m *Manager) PrintStats() {
+	st := m.Stats()
+	utils.PrintInfo("Wordlist stats: %d entries | %d unique | %d duplicates | avg length %.1f", st.Total, st.Unique, st.Duplicates, st.AvgLength)
+	if st.Dotted > 0 {
+		utils.PrintInfo("Wordlist stats: %d entries have an extension (file-like, skipped in directory phase unless -no-dot-skip)", st.Dotted)
+	}
+}
+
+// Hash returns the SHA-256 of the wordlist files (concatenated in order),
+// so list changes between scans are detectable
+func (m *Manager) Hash() (string, error) {
+	h := sha256.New()
+	for _, p := range m.paths {
+		if err := hashFile(h, p); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile feeds the content of path to w
+func hashFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open wordlist: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("error reading wordlist: %w", err)
+	}
+	return nil
 }
 
-// GetPath returns the wordlist path
+// GetPath returns the wordlist path (comma-separated for several files)
 func (m *Manager) GetPath() string {
-	return m.path
+	return strings.Join(m.paths, ",")
 }
 
 // Count returns the number of words loaded